@@ -0,0 +1,45 @@
+package pureport
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+// errNoAccountHref is returned when neither the resource nor the provider set an account
+var errNoAccountHref = fmt.Errorf("account_href has to be set, either on the resource or the provider")
+
+// defaultAccountHref is a CustomizeDiff that plans the account_href of the
+// provider when the resource doesn't set one.
+func defaultAccountHref(d *schema.ResourceDiff, m interface{}) error {
+
+	if _, ok := d.GetOk("account_href"); ok || !d.NewValueKnown("account_href") {
+		return nil
+	}
+
+	config := m.(*configuration.Config)
+	if config.AccountHref == "" {
+		return errNoAccountHref
+	}
+
+	return d.SetNew("account_href", config.AccountHref)
+}
+
+// getAccountHref returns the account_href of the data source, defaulting to the
+// account_href of the provider.
+func getAccountHref(d *schema.ResourceData, m interface{}) (string, error) {
+
+	if v, ok := d.GetOk("account_href"); ok {
+		return v.(string), nil
+	}
+
+	config := m.(*configuration.Config)
+	if config.AccountHref == "" {
+		return "", errNoAccountHref
+	}
+
+	d.Set("account_href", config.AccountHref)
+
+	return config.AccountHref, nil
+}
@@ -0,0 +1,55 @@
+package pureport
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+func TestGetAccountHref(t *testing.T) {
+
+	cases := []struct {
+		Raw         map[string]interface{}
+		Config      *configuration.Config
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Raw:      map[string]interface{}{"account_href": "/accounts/ac-1"},
+			Config:   &configuration.Config{AccountHref: "/accounts/ac-2"},
+			Expected: "/accounts/ac-1",
+		},
+		{
+			Raw:      map[string]interface{}{},
+			Config:   &configuration.Config{AccountHref: "/accounts/ac-2"},
+			Expected: "/accounts/ac-2",
+		},
+		{
+			Raw:         map[string]interface{}{},
+			Config:      &configuration.Config{},
+			ExpectError: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		d := schema.TestResourceDataRaw(t, dataSourceNetworks().Schema, c.Raw)
+
+		href, err := getAccountHref(d, c.Config)
+		if c.ExpectError {
+			if err == nil {
+				t.Errorf("%d: expected an error", i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %s", i, err)
+		}
+
+		if href != c.Expected || d.Get("account_href") != c.Expected {
+			t.Errorf("%d: expected %s, got %s", i, c.Expected, href)
+		}
+	}
+}
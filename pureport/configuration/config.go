@@ -1,15 +1,25 @@
+// Package configuration creates the authenticated Pureport API session used by the
+// provider. A Config can also be used directly, e.g.
+//
+//	config := configuration.Config{APIKey: key, APISecret: secret}
+//	err := config.LoadAndValidate()
 package configuration
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"sort"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform/httpclient"
 	"github.com/pureport/pureport-sdk-go/pureport"
 	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/pureport-sdk-go/pureport/credentials"
 	ppLog "github.com/pureport/pureport-sdk-go/pureport/logging"
 	"github.com/pureport/pureport-sdk-go/pureport/session"
 	"github.com/pureport/terraform-provider-pureport/version"
@@ -17,15 +27,66 @@ import (
 
 var (
 	logMutex sync.Mutex
+
+	// Pooled transport shared by the API clients of all provider instances
+	transport     *http.Transport
+	transportOnce sync.Once
 )
 
+// DefaultPollDelay is the delay before the state of an asynchronous operation is
+// polled for the first time, unless a poll interval is configured.
+const DefaultPollDelay = 5 * time.Second
+
+// maxIdleConnsPerHost allows the connections used by Terraform's default
+// parallelism to be kept alive and reused between requests.
+const maxIdleConnsPerHost = 10
+
 type Config struct {
 	Session *session.Session
 
 	APIKey                string
 	APISecret             string
 	AuthenticationProfile string
+	CredentialsFile       string
 	EndPoint              string
+
+	// The account resources and data sources use when they don't set one
+	AccountHref string
+
+	// Log the metadata of every API request with a correlation ID that is also
+	// sent to the Pureport API, so the logs can be matched by Pureport support.
+	LogRequests bool
+
+	// The number of times a request is retried after a transient failure
+	MaxRetries int
+
+	// Cancelled when Terraform is stopped, e.g. by Ctrl-C, to abort the requests
+	// in flight instead of waiting for them to complete.
+	StopContext context.Context
+
+	// The timeout of each attempt of a request to the Pureport API
+	RequestTimeout time.Duration
+
+	// The maximum rate of requests to the Pureport API, unlimited when zero
+	RequestsPerSecond float64
+
+	// The interval at which the state of asynchronous operations, e.g. the
+	// provisioning of connections, is polled. The default backs off between polls.
+	PollInterval time.Duration
+
+	// The timeout of asynchronous operations for resources without a timeout
+	// configured in their timeouts block.
+	DefaultWaitTimeout time.Duration
+
+	// The path to a file with PEM encoded CA certificates to trust in addition to
+	// the system ones, e.g. for a TLS intercepting proxy, and whether to skip the
+	// verification of the API certificate entirely.
+	CABundle           string
+	InsecureSkipVerify bool
+
+	// Supported connections by Account ID, cached for the life of the provider
+	supportedConnections      map[string][]client.SupportedConnection
+	supportedConnectionsMutex sync.Mutex
 }
 
 func (c *Config) LoadAndValidate() error {
@@ -39,6 +100,10 @@ func (c *Config) LoadAndValidate() error {
 		return fmt.Errorf("API Key and Secret both need to be specified for successful authentication.")
 	}
 
+	if err := c.resolveProfileCredentials(); err != nil {
+		return err
+	}
+
 	cfg := pureport.NewConfiguration()
 
 	if c.APIKey != "" {
@@ -57,6 +122,10 @@ func (c *Config) LoadAndValidate() error {
 		cfg.EndPoint = c.EndPoint
 	}
 
+	if c.RequestTimeout > 0 {
+		cfg.Timeout = c.RequestTimeout
+	}
+
 	logCfg := ppLog.NewLogConfig()
 
 	// Map Terrform Log Levels to our SDK Levels
@@ -77,14 +146,131 @@ func (c *Config) LoadAndValidate() error {
 
 	ppLog.SetupLogger(logCfg)
 
+	cfg.UserAgent = userAgent()
+	t, err := c.baseTransport(cfg.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	c.Session = session.NewSession(cfg)
+	c.Session.Credentials = newSessionCredentials(cfg, t)
+	c.Session.Client = c.newAPIClient(cfg, c.Session.Credentials, t)
+
+	// Exchange the API Key for a session token now, so invalid credentials fail
+	// the configuration of the provider instead of every request.
+	if _, err := c.Session.Credentials.Get(); err != nil {
+		return fmt.Errorf("Error authenticating with the Pureport API at %s: %s", cfg.EndPoint, err)
+	}
+
+	return nil
+}
+
+// GetPollDelay returns the delay before the state of an asynchronous operation is
+// polled for the first time.
+func (c *Config) GetPollDelay() time.Duration {
+
+	if c.PollInterval > 0 {
+		return c.PollInterval
+	}
+
+	return DefaultPollDelay
+}
+
+// userAgent returns the User-Agent sent with every API request, with the versions
+// of Terraform and the provider so Pureport support can match API traffic with
+// the provider release that sent it.
+func userAgent() string {
+
 	terraformVersion := httpclient.UserAgentString()
 	providerVersion := fmt.Sprintf("terraform-provider-pureport/%s", version.ProviderVersion)
 	terraformWebsite := "(+https://www.pureport.com)"
 
-	cfg.UserAgent = fmt.Sprintf("%s %s %s", terraformVersion, terraformWebsite, providerVersion)
-	c.Session = session.NewSession(cfg)
+	ua := fmt.Sprintf("%s %s %s", terraformVersion, terraformWebsite, providerVersion)
 
-	return nil
+	log.Printf("[DEBUG] Pureport API Client User Agent: %s", ua)
+
+	return ua
+}
+
+// sharedTransport returns the transport used for all API requests, so connections
+// are pooled and reused instead of being opened for every request.
+func sharedTransport() http.RoundTripper {
+
+	transportOnce.Do(func() {
+		transport = cleanhttp.DefaultPooledTransport()
+		transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	})
+
+	return transport
+}
+
+// newAPIClient creates the Pureport API client with a transport that retries
+// transient failures, refreshes the session credentials when a request is
+// rejected as unauthorized and cancels requests when Terraform is stopped.
+func (c *Config) newAPIClient(cfg *pureport.Configuration, cred *credentials.Credentials, t http.RoundTripper) *client.APIClient {
+
+	if c.LogRequests {
+		t = &loggingTransport{
+			correlationId: newCorrelationId(),
+			transport:     t,
+		}
+	}
+
+	t = &reauthTransport{
+		credentials: cred,
+		transport:   newRetryTransport(c.MaxRetries, cfg.Timeout, t),
+	}
+
+	if c.StopContext != nil {
+		t = &stopTransport{
+			ctx:       c.StopContext,
+			transport: t,
+		}
+	}
+
+	clientCfg := client.NewConfiguration()
+	clientCfg.UserAgent = cfg.UserAgent
+	clientCfg.BasePath = cfg.EndPoint
+	clientCfg.HTTPClient = &http.Client{
+		Transport: t,
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		clientCfg.Host = hostname
+	}
+
+	return client.NewAPIClient(clientCfg)
+}
+
+// GetSupportedConnections returns the connection types, speeds and locations
+// supported for the account. The result is cached so it is only requested once
+// per account, no matter how many connections are planned.
+func (c *Config) GetSupportedConnections(accountId string) ([]client.SupportedConnection, error) {
+
+	c.supportedConnectionsMutex.Lock()
+	defer c.supportedConnectionsMutex.Unlock()
+
+	if supported, ok := c.supportedConnections[accountId]; ok {
+		return supported, nil
+	}
+
+	ctx := c.Session.GetSessionContext()
+
+	supported, resp, err := c.Session.Client.SupportedConnectionsApi.GetAccountSupportedConnections(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("Error when Reading Supported Connections data: %v", APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Error Response while Reading Supported Connections data: code=%v", resp.StatusCode)
+	}
+
+	if c.supportedConnections == nil {
+		c.supportedConnections = make(map[string][]client.SupportedConnection)
+	}
+	c.supportedConnections[accountId] = supported
+
+	return supported, nil
 }
 
 func (c *Config) getAccounts() ([]client.Account, error) {
@@ -93,7 +279,7 @@ func (c *Config) getAccounts() ([]client.Account, error) {
 
 	accounts, resp, err := c.Session.Client.AccountsApi.FindAllAccounts(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("Error when Reading Pureport Account data: %v", err)
+		return nil, fmt.Errorf("Error when Reading Pureport Account data: %v", APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -134,7 +320,7 @@ func (c *Config) GetAccNetworks() ([]client.Network, error) {
 
 		networks, resp, err := c.Session.Client.NetworksApi.FindNetworks(ctx, account.Id)
 		if err != nil {
-			return nil, fmt.Errorf("Error when Reading Network data: %v", err)
+			return nil, fmt.Errorf("Error when Reading Network data: %v", APIError(err))
 		}
 
 		if resp.StatusCode >= 300 {
@@ -173,7 +359,7 @@ func (c *Config) GetAccConnections() ([]client.Connection, error) {
 
 		connections, resp, err := c.Session.Client.ConnectionsApi.GetConnections(ctx, network.Id)
 		if err != nil {
-			return nil, fmt.Errorf("Error when Reading Connections data: %v", err)
+			return nil, fmt.Errorf("Error when Reading Connections data: %v", APIError(err))
 		}
 
 		if resp.StatusCode >= 300 {
@@ -205,7 +391,7 @@ func (c *Config) SweepNetworks(networks []client.Network) error {
 		if _, ok := network.Tags["sweep"]; ok {
 			resp, err := c.Session.Client.NetworksApi.DeleteNetwork(ctx, network.Id)
 			if err != nil {
-				return fmt.Errorf("Error when Deleting Network: %v", err)
+				return fmt.Errorf("Error when Deleting Network: %v", APIError(err))
 			}
 
 			if resp.StatusCode >= 300 {
@@ -225,7 +411,7 @@ func (c *Config) SweepConnections(connections []client.Connection) error {
 		if _, ok := connection.Tags["sweep"]; ok {
 			_, resp, err := c.Session.Client.ConnectionsApi.DeleteConnection(ctx, connection.Id)
 			if err != nil {
-				return fmt.Errorf("Error when Deleting Connection: %v", err)
+				return fmt.Errorf("Error when Deleting Connection: %v", APIError(err))
 			}
 
 			if resp.StatusCode >= 300 {
@@ -0,0 +1,96 @@
+package configuration
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pureport/pureport-sdk-go/pureport"
+	"github.com/pureport/pureport-sdk-go/pureport/credentials"
+	"github.com/pureport/pureport-sdk-go/pureport/credentials/endpoint"
+)
+
+// tokenExpiryWindow is how long before its expiration the session token is
+// refreshed, so a request is never sent with a token that expires in flight.
+const tokenExpiryWindow = 1 * time.Minute
+
+// loadProfileCredentials reads the API Key and Secret of a named profile from the
+// shared credentials file, e.g.
+//
+//	[production]
+//	api_key = ...
+//	api_secret = ...
+//
+// The file defaults to $HOME/.pureport/credentials, or PUREPORT_CREDENTIALS_FILE,
+// and the profile to PUREPORT_PROFILE or "default".
+func loadProfileCredentials(filename string, profile string) (credentials.Value, error) {
+
+	provider := &credentials.FileProvider{
+		Filename: filename,
+		Profile:  profile,
+	}
+
+	value, err := provider.Retrieve()
+	if err != nil {
+		return value, err
+	}
+
+	log.Printf("[Info] Using the API Key of profile %q from the Pureport credentials file", provider.Profile)
+
+	return value, nil
+}
+
+// resolveProfileCredentials sets the API Key and Secret from the shared credentials
+// file when they are not configured explicitly, so the SDK session authenticates
+// with the selected profile.
+func (c *Config) resolveProfileCredentials() error {
+
+	if c.APIKey != "" || (c.AuthenticationProfile == "" && c.CredentialsFile == "") {
+		return nil
+	}
+
+	value, err := loadProfileCredentials(c.CredentialsFile, c.AuthenticationProfile)
+	if err != nil {
+
+		// Without an explicit file, fall back to the profiles in the Pureport
+		// configuration files read by the SDK.
+		if err == credentials.ErrorFileConfigurationFileNotFound && c.CredentialsFile == "" {
+			return nil
+		}
+
+		return fmt.Errorf("Error loading the credentials of profile %q: %s", c.AuthenticationProfile, err)
+	}
+
+	c.APIKey = value.APIKey
+	c.APISecret = value.Secret
+
+	return nil
+}
+
+// newSessionCredentials creates the credentials that exchange the API Key and
+// Secret for a bearer token at the API login endpoint. The token is requested
+// again shortly before it expires, so long running applies keep a valid token.
+func newSessionCredentials(cfg *pureport.Configuration, t http.RoundTripper) *credentials.Credentials {
+
+	keys := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.StaticProvider{
+			APIKey:    cfg.APIKey,
+			APISecret: cfg.APISecret,
+		},
+		&credentials.ViperProvider{
+			Profile: cfg.AuthenticationProfile,
+		},
+	})
+
+	return credentials.NewCredentials(&endpoint.Provider{
+		Client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: t,
+		},
+		EndPoint:     strings.TrimRight(cfg.EndPoint, "/"),
+		Credentials:  keys,
+		ExpiryWindow: tokenExpiryWindow,
+	})
+}
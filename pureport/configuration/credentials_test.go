@@ -0,0 +1,140 @@
+package configuration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pureport/pureport-sdk-go/pureport"
+)
+
+const testCredentialsFile = `
+[default]
+api_key = default-key
+api_secret = default-secret
+
+[production]
+api_key = production-key
+api_secret = production-secret
+`
+
+func writeTestCredentialsFile(t *testing.T) string {
+
+	dir, err := ioutil.TempDir("", "pureport")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	filename := filepath.Join(dir, "credentials")
+	if err := ioutil.WriteFile(filename, []byte(testCredentialsFile), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return filename
+}
+
+func TestResolveProfileCredentials(t *testing.T) {
+
+	filename := writeTestCredentialsFile(t)
+	defer os.RemoveAll(filepath.Dir(filename))
+
+	cases := []struct {
+		Config         *Config
+		ExpectedKey    string
+		ExpectedSecret string
+		ExpectError    bool
+	}{
+		{
+			Config:         &Config{CredentialsFile: filename},
+			ExpectedKey:    "default-key",
+			ExpectedSecret: "default-secret",
+		},
+		{
+			Config:         &Config{CredentialsFile: filename, AuthenticationProfile: "production"},
+			ExpectedKey:    "production-key",
+			ExpectedSecret: "production-secret",
+		},
+		{
+			Config:         &Config{CredentialsFile: filename, AuthenticationProfile: "production", APIKey: "key", APISecret: "secret"},
+			ExpectedKey:    "key",
+			ExpectedSecret: "secret",
+		},
+		{
+			Config:      &Config{CredentialsFile: filename, AuthenticationProfile: "staging"},
+			ExpectError: true,
+		},
+		{
+			Config:      &Config{CredentialsFile: filename + ".missing", AuthenticationProfile: "production"},
+			ExpectError: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		err := c.Config.resolveProfileCredentials()
+		if c.ExpectError {
+			if err == nil {
+				t.Errorf("%d: expected an error", i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %s", i, err)
+		}
+
+		if c.Config.APIKey != c.ExpectedKey || c.Config.APISecret != c.ExpectedSecret {
+			t.Errorf("%d: expected %s/%s, got %s/%s", i, c.ExpectedKey, c.ExpectedSecret, c.Config.APIKey, c.Config.APISecret)
+		}
+	}
+}
+
+func TestSessionCredentials(t *testing.T) {
+
+	logins := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path != "/login" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logins++
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": %d}`, logins, int(tokenExpiryWindow.Seconds())/2)
+	}))
+	defer server.Close()
+
+	cfg := &pureport.Configuration{
+		APIKey:    "key",
+		APISecret: "secret",
+		EndPoint:  server.URL + "/",
+	}
+
+	cred := newSessionCredentials(cfg, http.DefaultTransport)
+
+	value, err := cred.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if value.SessionToken != "token-1" {
+		t.Errorf("expected token-1, got %s", value.SessionToken)
+	}
+
+	// The token expires within the expiry window, so it is requested again
+	value, err = cred.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if value.SessionToken != "token-2" {
+		t.Errorf("expected the token to be refreshed before it expires, got %s", value.SessionToken)
+	}
+}
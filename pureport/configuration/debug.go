@@ -0,0 +1,114 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+// redacted replaces the values of secrets in the logged requests and responses
+const redacted = "<redacted>"
+
+// maxLoggedBody is the maximum number of bytes of a body that are logged
+const maxLoggedBody = 16 * 1024
+
+// secretField matches the names of JSON fields holding secrets, e.g. the API
+// Secret, session tokens, BGP passwords and the pre-shared keys of VPN gateways.
+var secretField = regexp.MustCompile(`(?i)(secret|password|token|key)$`)
+
+// debugTransport logs the requests to and responses from the Pureport API with
+// their bodies at the DEBUG level, so TF_LOG=DEBUG shows the actual API exchange.
+// Secrets in the bodies are redacted. The headers are only logged by the
+// loggingTransport, when log_requests is enabled.
+type debugTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	if !logging.IsDebugOrHigher() {
+		return t.transport.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		req = cloneRequest(req)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		reqBody = body
+	}
+
+	log.Printf("[DEBUG] Pureport API Request: %s %s%s", req.Method, req.URL, formatBody(reqBody))
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		log.Printf("[DEBUG] Pureport API Request %s %s failed: %s", req.Method, req.URL, err)
+		return resp, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	log.Printf("[DEBUG] Pureport API Response: %s %s: %s%s", req.Method, req.URL, resp.Status, formatBody(respBody))
+
+	return resp, nil
+}
+
+// formatBody returns the body for logging, with secrets redacted
+func formatBody(body []byte) string {
+
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		if redactedBody, err := json.MarshalIndent(redactSecrets(v), "", "  "); err == nil {
+			body = redactedBody
+		}
+	}
+
+	s := string(body)
+	if len(s) > maxLoggedBody {
+		s = s[:maxLoggedBody] + "... (truncated)"
+	}
+
+	return "\n" + strings.TrimSpace(s)
+}
+
+// redactSecrets replaces the string values of secret fields in a decoded JSON value
+func redactSecrets(v interface{}) interface{} {
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if s, ok := value.(string); ok && s != "" && secretField.MatchString(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactSecrets(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactSecrets(value)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,88 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormatBody(t *testing.T) {
+
+	body := formatBody([]byte(`{
+		"key": "api-key",
+		"secret": "api-secret",
+		"name": "Site VPN",
+		"enableBGPPassword": true,
+		"primaryKey": "psk-1",
+		"gateways": [{"bgpConfig": {"password": "bgp-password"}}]
+	}`))
+
+	for _, secret := range []string{"api-key", "api-secret", "psk-1", "bgp-password"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("expected %s to be redacted from %s", secret, body)
+		}
+	}
+
+	for _, value := range []string{"Site VPN", `"enableBGPPassword": true`} {
+		if !strings.Contains(body, value) {
+			t.Errorf("expected %s to be logged in %s", value, body)
+		}
+	}
+
+	if body := formatBody([]byte("not json")); body != "\nnot json" {
+		t.Errorf("expected the body to be logged as is, got %q", body)
+	}
+}
+
+func TestDebugTransport(t *testing.T) {
+
+	if v, ok := os.LookupEnv("TF_LOG"); ok {
+		defer os.Setenv("TF_LOG", v)
+	} else {
+		defer os.Unsetenv("TF_LOG")
+	}
+	os.Setenv("TF_LOG", "DEBUG")
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	var received string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		fmt.Fprint(w, `{"access_token": "session-token"}`)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: &debugTransport{transport: http.DefaultTransport}}
+
+	resp, err := httpClient.Post(server.URL+"/login", "application/json", strings.NewReader(`{"key": "api-key", "secret": "api-secret"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "session-token") || !strings.Contains(received, "api-secret") {
+		t.Errorf("expected the request and response bodies to be passed through, got %s and %s", received, body)
+	}
+
+	logged := output.String()
+
+	for _, expected := range []string{"POST " + server.URL + "/login", "200 OK"} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("expected %q to be logged, got %s", expected, logged)
+		}
+	}
+
+	if strings.Contains(logged, "session-token") || strings.Contains(logged, "api-secret") {
+		t.Errorf("expected secrets to be redacted, got %s", logged)
+	}
+}
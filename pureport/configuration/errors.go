@@ -0,0 +1,93 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+// FieldError is the validation error of a single field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse is an error returned by the Pureport API, along with the details
+// from the body of the error response.
+type ErrorResponse struct {
+	Status  string       `json:"-"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
+
+func (e *ErrorResponse) Error() string {
+
+	var b strings.Builder
+	b.WriteString(e.Status)
+
+	if e.Code != "" {
+		fmt.Fprintf(&b, ": %s", e.Code)
+	}
+
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+
+	for i, f := range e.Errors {
+
+		if i == 0 {
+			b.WriteString(" (")
+		} else {
+			b.WriteString(", ")
+		}
+
+		if f.Field != "" {
+			fmt.Fprintf(&b, "%s: ", f.Field)
+		}
+		b.WriteString(f.Message)
+	}
+
+	if len(e.Errors) > 0 {
+		b.WriteString(")")
+	}
+
+	return b.String()
+}
+
+// APIError returns the error of a request to the Pureport API with the code,
+// message and field errors of the error response. Errors that aren't error
+// responses, or whose body can't be parsed, are returned as is.
+func APIError(err error) error {
+
+	swerr, ok := err.(client.GenericSwaggerError)
+	if !ok {
+		return err
+	}
+
+	if e := parseErrorResponse(swerr.Error(), swerr.Body()); e != nil {
+		return e
+	}
+
+	return err
+}
+
+// parseErrorResponse parses the body of an error response, returning nil when
+// the body doesn't describe the error.
+func parseErrorResponse(status string, body []byte) *ErrorResponse {
+
+	e := &ErrorResponse{}
+	if err := json.Unmarshal(body, e); err != nil {
+		return nil
+	}
+
+	if e.Code == "" && e.Message == "" && len(e.Errors) == 0 {
+		return nil
+	}
+
+	e.Status = status
+
+	return e
+}
@@ -0,0 +1,63 @@
+package configuration
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseErrorResponse(t *testing.T) {
+
+	cases := []struct {
+		Body     string
+		Expected string
+	}{
+		{
+			Body:     `{"status": 400, "code": "INVALID_NAME", "message": "The name is already in use"}`,
+			Expected: "400 Bad Request: INVALID_NAME: The name is already in use",
+		},
+		{
+			Body:     `{"status": 400, "message": "Validation failed", "errors": [{"field": "speed", "message": "must be one of 50, 100"}, {"message": "too many connections"}]}`,
+			Expected: "400 Bad Request: Validation failed (speed: must be one of 50, 100, too many connections)",
+		},
+		{
+			Body: `{"status": 400}`,
+		},
+		{
+			Body: `<html>Bad Request</html>`,
+		},
+	}
+
+	for _, c := range cases {
+
+		e := parseErrorResponse("400 Bad Request", []byte(c.Body))
+
+		if c.Expected == "" {
+			if e != nil {
+				t.Errorf("expected no details for %s, got %q", c.Body, e)
+			}
+			continue
+		}
+
+		if e == nil {
+			t.Errorf("expected details for %s", c.Body)
+			continue
+		}
+
+		if e.Error() != c.Expected {
+			t.Errorf("expected %q, got %q", c.Expected, e)
+		}
+	}
+}
+
+func TestAPIError(t *testing.T) {
+
+	err := fmt.Errorf("connection refused")
+
+	if APIError(err) != err {
+		t.Errorf("expected errors that aren't error responses to be returned as is")
+	}
+
+	if APIError(nil) != nil {
+		t.Errorf("expected nil to be returned as is")
+	}
+}
@@ -0,0 +1,70 @@
+package configuration
+
+import (
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that allows a burst of up to one second of
+// requests, and then limits requests to the configured rate.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+
+	burst := math.Max(1, math.Ceil(requestsPerSecond))
+
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait before the
+// request can be sent. Tokens are borrowed while the bucket is empty, so waiting
+// requests are sent in order at the configured rate.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// rateLimitTransport limits the rate of requests sent to the Pureport API, so
+// large plans don't get the provider throttled.
+type rateLimitTransport struct {
+	limiter   *rateLimiter
+	transport http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	if delay := t.limiter.reserve(time.Now()); delay > 0 {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,69 @@
+package configuration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+
+	limiter := newRateLimiter(2)
+	now := time.Now()
+
+	// The burst is sent without waiting
+	for i := 0; i < 2; i++ {
+		if delay := limiter.reserve(now); delay != 0 {
+			t.Errorf("%d: expected no delay within the burst, got %s", i, delay)
+		}
+	}
+
+	// Further requests are spaced at the rate
+	if delay := limiter.reserve(now); delay != 500*time.Millisecond {
+		t.Errorf("expected a delay of 500ms, got %s", delay)
+	}
+
+	if delay := limiter.reserve(now); delay != time.Second {
+		t.Errorf("expected a delay of 1s, got %s", delay)
+	}
+
+	// The bucket refills over time, up to the burst
+	if delay := limiter.reserve(now.Add(10 * time.Second)); delay != 0 {
+		t.Errorf("expected no delay after the bucket refilled, got %s", delay)
+	}
+
+	if limiter.tokens != limiter.burst-1 {
+		t.Errorf("expected the bucket to be refilled up to the burst, got %v tokens", limiter.tokens)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{
+		Transport: &rateLimitTransport{
+			limiter:   newRateLimiter(20),
+			transport: http.DefaultTransport,
+		},
+	}
+
+	start := time.Now()
+
+	for i := 0; i < 25; i++ {
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	// 20 requests are sent as a burst, the other 5 at 20 per second
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected the requests to be rate limited, took %s", elapsed)
+	}
+}
@@ -0,0 +1,217 @@
+package configuration
+
+import (
+	"context"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried after a
+	// transient failure, unless configured otherwise. With the backoff below, a
+	// request gives up after about 10 minutes.
+	DefaultMaxRetries = 25
+
+	// Bounds of the exponential backoff between retries
+	minRetryBackoff = 1 * time.Second
+	maxRetryBackoff = 30 * time.Second
+
+	// maxConflictRetries bounds the retries of a change rejected with a 409.
+	// Conflicts with a concurrent change of the same network clear within a
+	// minute, while other conflicts, e.g. an invalid state, never do.
+	maxConflictRetries = 6
+)
+
+// retryTransport retries requests that fail with a transient error, e.g. the API
+// being throttled, temporarily unavailable or rejecting a change that conflicts
+// with a concurrent one, with exponential backoff and jitter.
+// The timeout applies to each attempt, so retries aren't cut short by a timeout
+// for the request as a whole.
+type retryTransport struct {
+	maxRetries int
+	timeout    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	transport  http.RoundTripper
+}
+
+func newRetryTransport(maxRetries int, timeout time.Duration, transport http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		maxRetries: maxRetries,
+		timeout:    timeout,
+		minBackoff: minRetryBackoff,
+		maxBackoff: maxRetryBackoff,
+		transport:  transport,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	conflicts := 0
+
+	for attempt := 0; ; attempt++ {
+
+		r := req
+		if attempt > 0 {
+			r = cloneRequest(req)
+
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := t.roundTrip(r)
+
+		if attempt >= t.maxRetries || !isRetryable(req, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			if conflicts >= maxConflictRetries {
+				return resp, err
+			}
+			conflicts++
+		}
+
+		delay := t.backoff(attempt, resp)
+
+		// Don't retry before the API said to, when that's past the deadline
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			log.Printf("[Info] %s %s not retried, the retry in %s is past the deadline of the request", req.Method, req.URL.Path, delay)
+			return resp, err
+		}
+
+		if err != nil {
+			log.Printf("[Info] %s %s failed, retrying in %s (%d/%d): %s", req.Method, req.URL.Path, delay, attempt+1, t.maxRetries, err)
+		} else {
+			log.Printf("[Info] %s %s returned %s, retrying in %s (%d/%d)", req.Method, req.URL.Path, resp.Status, delay, attempt+1, t.maxRetries)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// roundTrip sends a single attempt of the request, which is cancelled when it
+// takes longer than the timeout, including reading the response body.
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+
+	if t.timeout <= 0 {
+		return t.transport.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return resp, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelBody releases the timeout of a request once its response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isRetryable returns whether the request failed with a transient error and can
+// be sent again.
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+
+	// The body has to be replayed for the retry
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		// The request may have been processed, so only retry requests that can be
+		// safely repeated.
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+
+	// A throttled request was rejected before being processed, so it can be
+	// retried regardless of the method.
+	case http.StatusTooManyRequests:
+		return true
+
+	// A change that conflicts with a concurrent one, e.g. creating connections
+	// in the same network in parallel, is retried until that one is done. The
+	// resources check the name is available before creating, so a create isn't
+	// retried for a duplicate name until the conflict retries run out.
+	case http.StatusConflict:
+		return true
+
+	// The request may have been processed before the gateway gave up on it, so
+	// only retry requests that can be safely repeated. Failed creates are retried
+	// by the resources, which check whether the object was created first.
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+// isIdempotent returns whether sending a request with the method more than once
+// has the same effect as sending it once.
+func isIdempotent(method string) bool {
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns the delay before the next retry. The delay doubles for each
+// attempt up to the maximum, with random jitter so parallel requests don't retry
+// in lockstep. A Retry-After header sent by the API takes precedence, even when
+// it's longer than the maximum, so the API isn't retried before it said to.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	delay := t.maxBackoff
+	if attempt < 32 {
+		if d := t.minBackoff << uint(attempt); d > 0 && d < t.maxBackoff {
+			delay = d
+		}
+	}
+
+	// Full jitter between half and all of the delay
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
@@ -0,0 +1,276 @@
+package configuration
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRetryTransport(maxRetries int) *retryTransport {
+	return &retryTransport{
+		maxRetries: maxRetries,
+		minBackoff: time.Millisecond,
+		maxBackoff: 10 * time.Millisecond,
+		transport:  http.DefaultTransport,
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: testRetryTransport(5)}
+
+	req, _ := http.NewRequest("PUT", server.URL, strings.NewReader(`{"name": "test"}`))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the request to be retried until it succeeds, got status %d", resp.StatusCode)
+	}
+
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(bodies))
+	}
+
+	for _, b := range bodies {
+		if b != `{"name": "test"}` {
+			t.Errorf("expected the body to be replayed, got %q", b)
+		}
+	}
+}
+
+func TestRetryTransport_maxRetries(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: testRetryTransport(2)}
+
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", resp.StatusCode)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected the request to be retried twice, got %d requests", requests)
+	}
+}
+
+func TestRetryTransport_conflict(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: testRetryTransport(DefaultMaxRetries)}
+
+	req, _ := http.NewRequest("PUT", server.URL, strings.NewReader(`{"name": "test"}`))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	if requests != maxConflictRetries+1 {
+		t.Errorf("expected the conflicting request to be retried %d times, got %d requests", maxConflictRetries, requests)
+	}
+}
+
+func TestRetryTransport_conflictCreate(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: testRetryTransport(DefaultMaxRetries)}
+
+	req, _ := http.NewRequest("POST", server.URL, strings.NewReader(`{"name": "test"}`))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated || requests != 3 {
+		t.Errorf("expected the POST to be retried until the conflict cleared, got %d requests with status %d", requests, resp.StatusCode)
+	}
+}
+
+func TestRetryTransport_notRetryable(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: testRetryTransport(5)}
+
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusBadRequest || requests != 1 {
+		t.Errorf("expected a single request with status 400, got %d requests with status %d", requests, resp.StatusCode)
+	}
+}
+
+func TestRetryTransport_notIdempotent(t *testing.T) {
+
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+
+		requests := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(status)
+		}))
+
+		httpClient := &http.Client{Transport: testRetryTransport(5)}
+
+		req, _ := http.NewRequest("POST", server.URL, strings.NewReader(`{"name": "test"}`))
+
+		resp, err := httpClient.Do(req)
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if resp.StatusCode != status || requests != 1 {
+			t.Errorf("expected a single POST with status %d, got %d requests with status %d", status, requests, resp.StatusCode)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+
+	transport := newRetryTransport(DefaultMaxRetries, 0, http.DefaultTransport)
+
+	for attempt := 0; attempt < 40; attempt++ {
+
+		delay := transport.backoff(attempt, nil)
+		if delay < transport.minBackoff/2 || delay > transport.maxBackoff {
+			t.Errorf("attempt %d: expected the delay to be between %s and %s, got %s", attempt, transport.minBackoff/2, transport.maxBackoff, delay)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if delay := transport.backoff(0, resp); delay != 3*time.Second {
+		t.Errorf("expected the Retry-After delay, got %s", delay)
+	}
+
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if delay := transport.backoff(0, resp); delay != 2*time.Minute {
+		t.Errorf("expected the Retry-After delay beyond the maximum backoff, got %s", delay)
+	}
+}
+
+func TestRetryTransport_retryAfterDeadline(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+
+	start := time.Now()
+
+	resp, err := (&http.Client{Transport: testRetryTransport(5)}).Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || requests != 1 {
+		t.Errorf("expected the request not to be retried past the deadline, got %d requests with status %d", requests, resp.StatusCode)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the request to return without waiting for the retry, took %s", elapsed)
+	}
+}
+
+func TestRetryTransport_timeout(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := testRetryTransport(2)
+	transport.timeout = 50 * time.Millisecond
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || requests != 2 {
+		t.Errorf("expected the timed out request to be retried, got %d requests with status %d", requests, resp.StatusCode)
+	}
+}
@@ -0,0 +1,84 @@
+package configuration
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// loadCABundle returns the system certificate pool with the PEM encoded
+// certificates of the CA bundle file added to it.
+func loadCABundle(filename string) (*x509.CertPool, error) {
+
+	pem, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading CA bundle %s: %s", filename, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("Error reading CA bundle %s: no PEM encoded certificates found", filename)
+	}
+
+	return pool, nil
+}
+
+// baseTransport returns the transport the requests to the Pureport API are sent
+// with, including the login requests. The shared transport is used unless custom
+// TLS settings are configured, the requests are logged when debug logging is
+// enabled and their rate is limited when configured.
+func (c *Config) baseTransport(userAgent string) (http.RoundTripper, error) {
+
+	if c.CABundle == "" && !c.InsecureSkipVerify {
+		return c.newBaseTransport(userAgent, sharedTransport()), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.CABundle != "" {
+		pool, err := loadCABundle(c.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.InsecureSkipVerify {
+		log.Printf("[WARN] TLS certificate verification of the Pureport API is disabled")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	t := cleanhttp.DefaultPooledTransport()
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	t.TLSClientConfig = tlsConfig
+
+	return c.newBaseTransport(userAgent, t), nil
+}
+
+func (c *Config) newBaseTransport(userAgent string, t http.RoundTripper) http.RoundTripper {
+
+	t = &debugTransport{transport: t}
+
+	if c.RequestsPerSecond > 0 {
+		t = &rateLimitTransport{
+			limiter:   newRateLimiter(c.RequestsPerSecond),
+			transport: t,
+		}
+	}
+
+	return &userAgentTransport{
+		userAgent: userAgent,
+		transport: t,
+	}
+}
@@ -0,0 +1,73 @@
+package configuration
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBaseTransport(t *testing.T) {
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pureport")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	bundle := filepath.Join(dir, "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(bundle, cert, 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		Config      *Config
+		ExpectError bool
+	}{
+		{Config: &Config{}, ExpectError: true},
+		{Config: &Config{CABundle: bundle}},
+		{Config: &Config{InsecureSkipVerify: true}},
+	}
+
+	for i, c := range cases {
+
+		transport, err := c.Config.baseTransport("test")
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %s", i, err)
+		}
+
+		_, err = (&http.Client{Transport: transport}).Get(server.URL)
+		if c.ExpectError != (err != nil) {
+			t.Errorf("%d: expected error %t, got %v", i, c.ExpectError, err)
+		}
+	}
+}
+
+func TestLoadCABundle_invalid(t *testing.T) {
+
+	if _, err := loadCABundle("missing.pem"); err == nil {
+		t.Errorf("expected an error for a missing CA bundle")
+	}
+
+	f, err := ioutil.TempFile("", "pureport")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer os.Remove(f.Name())
+
+	f.WriteString("not a certificate")
+	f.Close()
+
+	if _, err := loadCABundle(f.Name()); err == nil {
+		t.Errorf("expected an error for a CA bundle without certificates")
+	}
+}
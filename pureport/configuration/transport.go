@@ -0,0 +1,200 @@
+package configuration
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/pureport/pureport-sdk-go/pureport/credentials"
+)
+
+// reauthTransport refreshes the session credentials and retries the request once
+// when the Pureport API responds with a 401, e.g. because the session token expired
+// or was revoked during a long running apply.
+type reauthTransport struct {
+	credentials *credentials.Credentials
+	transport   http.RoundTripper
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Only authenticated requests with a body that can be replayed are retried
+	if req.Header.Get("Authorization") == "" || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+
+	log.Printf("[Info] Received 401 for %s %s, refreshing credentials", req.Method, req.URL.Path)
+
+	t.credentials.Expire()
+	value, cerr := t.credentials.Get()
+	if cerr != nil {
+		log.Printf("[Info] Unable to refresh credentials: %s", cerr)
+		return resp, err
+	}
+
+	retry := cloneRequest(req)
+	retry.Header.Set("Authorization", "Bearer "+value.SessionToken)
+
+	if req.GetBody != nil {
+		body, berr := req.GetBody()
+		if berr != nil {
+			return resp, err
+		}
+		retry.Body = body
+	}
+
+	resp.Body.Close()
+
+	return t.transport.RoundTrip(retry)
+}
+
+// cloneRequest returns a shallow copy of the request with its own headers, as
+// RoundTrippers must not modify the request they are given.
+func cloneRequest(req *http.Request) *http.Request {
+
+	r := new(http.Request)
+	*r = *req
+
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+
+	return r
+}
+
+// userAgentTransport sets the User-Agent of every request, including the login
+// requests that aren't sent by the API client.
+type userAgentTransport struct {
+	userAgent string
+	transport http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	req = cloneRequest(req)
+	req.Header.Set("User-Agent", t.userAgent)
+
+	return t.transport.RoundTrip(req)
+}
+
+// errStopped is returned for requests cancelled because Terraform is stopping
+var errStopped = errors.New("request cancelled, Terraform is stopping")
+
+// stopTransport cancels requests when the stop context is done, so requests in
+// flight and waiting for a retry are aborted when Terraform is interrupted.
+type stopTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+func (t *stopTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	if t.ctx.Err() != nil {
+		return nil, errStopped
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+
+	go func() {
+		select {
+		case <-t.ctx.Done():
+			log.Printf("[WARN] Cancelling %s %s, Terraform is stopping", req.Method, req.URL.Path)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	resp, err := t.transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if t.ctx.Err() != nil {
+			return nil, errStopped
+		}
+		return resp, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// CorrelationHeader is the header used to send the correlation ID of the requests
+// made by the provider to the Pureport API.
+const CorrelationHeader = "X-Correlation-Id"
+
+// newCorrelationId returns a random ID for the requests of a single Terraform run.
+func newCorrelationId() string {
+
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("tf-%d", time.Now().UnixNano())
+	}
+
+	return "tf-" + hex.EncodeToString(b)
+}
+
+// loggingTransport logs the metadata and headers of each request and response,
+// tagged with a correlation ID that is also sent to the Pureport API as a header.
+// The bodies are only logged by the debugTransport.
+type loggingTransport struct {
+	correlationId string
+	transport     http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	req = cloneRequest(req)
+	req.Header.Set(CorrelationHeader, t.correlationId)
+
+	log.Printf("[TRACE] [%s] Request: %s %s", t.correlationId, req.Method, req.URL)
+	t.logHeaders(req.Header)
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		log.Printf("[TRACE] [%s] Request failed after %s: %s", t.correlationId, duration, err)
+		return resp, err
+	}
+
+	log.Printf("[TRACE] [%s] Response: %s (%s) content-length=%d", t.correlationId, resp.Status, duration, resp.ContentLength)
+	t.logHeaders(resp.Header)
+
+	return resp, nil
+}
+
+// logHeaders logs the headers of a request or response, with the values of the
+// sensitive headers redacted.
+func (t *loggingTransport) logHeaders(header http.Header) {
+
+	for k, v := range header {
+		if isSensitiveHeader(k) {
+			v = []string{redacted}
+		}
+		log.Printf("[TRACE] [%s]   %s: %v", t.correlationId, k, v)
+	}
+}
+
+// isSensitiveHeader returns whether the values of the header hold credentials,
+// e.g. the session token, cookies or headers named like a secret field.
+func isSensitiveHeader(name string) bool {
+
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+		return true
+	}
+
+	return secretField.MatchString(name)
+}
@@ -0,0 +1,259 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pureport/pureport-sdk-go/pureport/credentials"
+)
+
+type testTokenProvider struct {
+	retrieved int
+}
+
+func (p *testTokenProvider) Retrieve() (credentials.Value, error) {
+	p.retrieved++
+	return credentials.Value{SessionToken: fmt.Sprintf("token-%d", p.retrieved)}, nil
+}
+
+func (p *testTokenProvider) IsExpired() bool {
+	return p.retrieved == 0
+}
+
+func TestReauthTransport(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	provider := &testTokenProvider{}
+	cred := credentials.NewCredentials(provider)
+
+	value, err := cred.Get()
+	if err != nil {
+		t.Fatalf("unexpected error getting credentials: %s", err)
+	}
+
+	httpClient := &http.Client{
+		Transport: &reauthTransport{
+			credentials: cred,
+			transport:   http.DefaultTransport,
+		},
+	}
+
+	req, _ := http.NewRequest("POST", server.URL, strings.NewReader("{}"))
+	req.Header.Set("Authorization", "Bearer "+value.SessionToken)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the request to be retried with refreshed credentials, got status %d", resp.StatusCode)
+	}
+
+	if provider.retrieved != 2 {
+		t.Errorf("expected credentials to be retrieved twice, got %d", provider.retrieved)
+	}
+
+	if req.Header.Get("Authorization") != "Bearer token-1" {
+		t.Errorf("expected the original request to be unchanged, got %s", req.Header.Get("Authorization"))
+	}
+}
+
+func TestReauthTransport_unauthenticated(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := &testTokenProvider{}
+
+	httpClient := &http.Client{
+		Transport: &reauthTransport{
+			credentials: credentials.NewCredentials(provider),
+			transport:   http.DefaultTransport,
+		},
+	}
+
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+
+	if provider.retrieved != 0 {
+		t.Errorf("expected credentials not to be refreshed, got %d retrievals", provider.retrieved)
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+
+	var received []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(CorrelationHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	correlationId := newCorrelationId()
+
+	httpClient := &http.Client{
+		Transport: &loggingTransport{
+			correlationId: correlationId,
+			transport:     http.DefaultTransport,
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+
+		if _, err := httpClient.Do(req); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if req.Header.Get(CorrelationHeader) != "" {
+			t.Errorf("expected the original request to be unchanged")
+		}
+	}
+
+	for _, id := range received {
+		if id != correlationId {
+			t.Errorf("expected correlation id %s, got %q", correlationId, id)
+		}
+	}
+
+	if !strings.HasPrefix(correlationId, "tf-") || correlationId == newCorrelationId() {
+		t.Errorf("unexpected correlation id %s", correlationId)
+	}
+}
+
+func TestLoggingTransport_redactsHeaders(t *testing.T) {
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "session-cookie"})
+		w.Header().Set("X-Auth-Token", "session-token")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{
+		Transport: &loggingTransport{
+			correlationId: newCorrelationId(),
+			transport:     http.DefaultTransport,
+		},
+	}
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set("Authorization", "Bearer request-token")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.Header.Get("X-Auth-Token") != "session-token" {
+		t.Errorf("expected the response headers to be passed through, got %v", resp.Header)
+	}
+
+	logged := output.String()
+
+	for _, secret := range []string{"request-token", "session-cookie", "session-token"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %q to be redacted from the log:\n%s", secret, logged)
+		}
+	}
+
+	if !strings.Contains(logged, "Set-Cookie: ["+redacted+"]") {
+		t.Errorf("expected the redacted Set-Cookie header to be logged:\n%s", logged)
+	}
+}
+
+func TestUserAgentTransport(t *testing.T) {
+
+	var received string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ua := userAgent()
+
+	if !strings.Contains(ua, "terraform-provider-pureport/") || !strings.Contains(ua, "Terraform/") {
+		t.Errorf("expected the provider and Terraform versions in the User-Agent, got %s", ua)
+	}
+
+	httpClient := &http.Client{
+		Transport: &userAgentTransport{
+			userAgent: ua,
+			transport: http.DefaultTransport,
+		},
+	}
+
+	if _, err := httpClient.Post(server.URL+"/login", "application/json", strings.NewReader("{}")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if received != ua {
+		t.Errorf("expected User-Agent %q, got %q", ua, received)
+	}
+}
+
+func TestStopTransport(t *testing.T) {
+
+	started := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, stop := context.WithCancel(context.Background())
+
+	httpClient := &http.Client{
+		Transport: &stopTransport{
+			ctx:       ctx,
+			transport: http.DefaultTransport,
+		},
+	}
+
+	go func() {
+		<-started
+		stop()
+	}()
+
+	if _, err := httpClient.Get(server.URL); err == nil || !strings.Contains(err.Error(), errStopped.Error()) {
+		t.Errorf("expected the request in flight to be cancelled, got %v", err)
+	}
+
+	if _, err := httpClient.Get(server.URL); err == nil || !strings.Contains(err.Error(), errStopped.Error()) {
+		t.Errorf("expected requests after the stop to be cancelled, got %v", err)
+	}
+}
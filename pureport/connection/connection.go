@@ -1,12 +1,22 @@
+// Package connection contains the schema, expand/flatten and wait helpers shared
+// by the Pureport connection resources. The helpers that don't take a
+// *schema.ResourceData, e.g. WaitForConnectionActive and ConnectionHealth, can be
+// used outside of Terraform with a configuration.Config.
 package connection
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -19,12 +29,30 @@ import (
 const (
 	AwsConnectionName     = "AWS Cloud Connection"
 	AzureConnectionName   = "Azure Cloud Connection"
+	DummyConnectionName   = "Dummy Connection"
 	GoogleConnectionName  = "Google Cloud Connection"
 	SiteVPNConnectionName = "SiteVPN Connection"
 )
 
+// connectionTypes maps the connection names to the connection types used by the API
+var connectionTypes = map[string]string{
+	AwsConnectionName:     "AWS_DIRECT_CONNECT",
+	AzureConnectionName:   "AZURE_EXPRESS_ROUTE",
+	DummyConnectionName:   "DUMMY",
+	GoogleConnectionName:  "GOOGLE_CLOUD_INTERCONNECT",
+	SiteVPNConnectionName: "SITE_IPSEC_VPN",
+}
+
 var (
 	StandardGatewaySchema = map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"href": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 		"availability_domain": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -74,9 +102,30 @@ var (
 			Type:     schema.TypeInt,
 			Computed: true,
 		},
+		"state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"link_state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"bgp_state": {
+			Type:        schema.TypeString,
+			Description: "The state of the BGP session for the gateway, e.g. ESTABLISHED or IDLE.",
+			Computed:    true,
+		},
 	}
 
 	VpnGatewaySchema = map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"href": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 		"availability_domain": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -142,6 +191,24 @@ var (
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		"ipsec_status": {
+			Type:        schema.TypeString,
+			Description: "The status of the IPSec tunnel/security associations for the gateway.",
+			Computed:    true,
+		},
+		"state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"link_state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"bgp_state": {
+			Type:        schema.TypeString,
+			Description: "The state of the BGP session for the gateway, e.g. ESTABLISHED or IDLE.",
+			Computed:    true,
+		},
 	}
 )
 
@@ -151,11 +218,47 @@ func GetBaseResourceConnectionSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Required: true,
 		},
+		"speed": {
+			Type:             schema.TypeString,
+			Description:      "The speed of the connection in Mbps, or with a unit, e.g. 1Gbps.",
+			Required:         true,
+			ValidateFunc:     ValidateSpeedFormat,
+			DiffSuppressFunc: SuppressEquivalentSpeedDiffs,
+		},
 		"href": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
 		"state": {
+			Type:        schema.TypeString,
+			Description: "The provisioning state of the connection, e.g. ACTIVE, PROVISIONING or FAILED_TO_PROVISION.",
+			Computed:    true,
+		},
+		"health": {
+			Type:        schema.TypeString,
+			Description: "Aggregate health of the connection and its gateways: [HEALTHY, DEGRADED, DOWN, UNKNOWN]",
+			Computed:    true,
+		},
+		"estimated_monthly_cost": {
+			Type:        schema.TypeFloat,
+			Description: "The estimated monthly cost of the connection, based on the Pureport billing plans.",
+			Computed:    true,
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Description: "The RFC3339 time the connection was created.",
+			Computed:    true,
+		},
+		"active_at": {
+			Type:        schema.TypeString,
+			Description: "The RFC3339 time the connection last became active.",
+			Computed:    true,
+		},
+		"error_code": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"error_message": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
@@ -165,8 +268,15 @@ func GetBaseResourceConnectionSchema() map[string]*schema.Schema {
 			ForceNew: true,
 		},
 		"network_href": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:        schema.TypeString,
+			Description: "The network for the connection. Connections can't be moved between networks, so changing this forces a new connection.",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"account_href": {
+			Type:        schema.TypeString,
+			Description: "The account that owns the network of the connection.",
+			Computed:    true,
 		},
 		"description": {
 			Type:     schema.TypeString,
@@ -175,16 +285,20 @@ func GetBaseResourceConnectionSchema() map[string]*schema.Schema {
 		"customer_networks": {
 			Type:     schema.TypeSet,
 			Optional: true,
+			Set:      hashCustomerNetwork,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"name": {
-						Type:     schema.TypeString,
-						Required: true,
+						Type:        schema.TypeString,
+						Description: "The name for the network. Defaults to the CIDR block of the network.",
+						Optional:    true,
+						Computed:    true,
 					},
 					"address": {
-						Type:         schema.TypeString,
-						Required:     true,
-						ValidateFunc: validation.CIDRNetwork(16, 32),
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateFunc:     validation.CIDRNetwork(16, 32),
+						DiffSuppressFunc: SuppressEquivalentCIDRDiffs,
 					},
 				},
 			},
@@ -204,11 +318,13 @@ func GetBaseResourceConnectionSchema() map[string]*schema.Schema {
 					"mappings": {
 						Type:     schema.TypeSet,
 						Optional: true,
+						Set:      hashNatMapping,
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
 								"native_cidr": {
-									Type:     schema.TypeString,
-									Required: true,
+									Type:             schema.TypeString,
+									Required:         true,
+									DiffSuppressFunc: SuppressEquivalentCIDRDiffs,
 								},
 								"nat_cidr": {
 									Type:     schema.TypeString,
@@ -218,9 +334,15 @@ func GetBaseResourceConnectionSchema() map[string]*schema.Schema {
 						},
 					},
 					"blocks": {
-						Type:     schema.TypeList,
-						Computed: true,
-						Elem:     &schema.Schema{Type: schema.TypeString},
+						Type:        schema.TypeList,
+						Description: "The CIDR blocks to allocate NAT addresses from. Allocated by Pureport when omitted.",
+						Optional:    true,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type:             schema.TypeString,
+							ValidateFunc:     validation.CIDRNetwork(8, 32),
+							DiffSuppressFunc: SuppressEquivalentCIDRDiffs,
+						},
 					},
 					"pnat_cidr": {
 						Type:     schema.TypeString,
@@ -244,6 +366,43 @@ func GetBaseResourceConnectionSchema() map[string]*schema.Schema {
 			Optional: true,
 			ForceNew: true,
 		},
+		"adopt_existing": {
+			Type:        schema.TypeBool,
+			Description: "Manage an existing connection with the same name in the network instead of failing to create the connection.",
+			Optional:    true,
+			Default:     false,
+		},
+		"allow_ha_downgrade": {
+			Type:        schema.TypeBool,
+			Description: "Allow high_availability to be disabled on an existing connection, which removes its redundant gateway.",
+			Optional:    true,
+			Default:     false,
+		},
+		"deletion_protection": {
+			Type:        schema.TypeBool,
+			Description: "Prevent the connection from being deleted while enabled.",
+			Optional:    true,
+			Default:     false,
+		},
+		"provisioning_retries": {
+			Type:         schema.TypeInt,
+			Description:  "The number of times to delete and recreate the connection when it fails to provision with a retryable error.",
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, 5),
+		},
+		"wait_for_bgp": {
+			Type:        schema.TypeBool,
+			Description: "Wait for the BGP sessions of all gateways to be established before completing.",
+			Optional:    true,
+			Default:     false,
+		},
+		"wait_for_provisioning": {
+			Type:        schema.TypeBool,
+			Description: "Wait for the connection to become active before completing.",
+			Optional:    true,
+			Default:     true,
+		},
 		"tags": tags.TagsSchema(),
 	}
 }
@@ -260,6 +419,30 @@ func GetBaseDataSourceConnectionSchema() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"state": {
+			Type:        schema.TypeString,
+			Description: "The provisioning state of the connection, e.g. ACTIVE, PROVISIONING or FAILED_TO_PROVISION.",
+			Computed:    true,
+		},
+		"health": {
+			Type:        schema.TypeString,
+			Description: "Aggregate health of the connection and its gateways: [HEALTHY, DEGRADED, DOWN, UNKNOWN]",
+			Computed:    true,
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Description: "The RFC3339 time the connection was created.",
+			Computed:    true,
+		},
+		"active_at": {
+			Type:        schema.TypeString,
+			Description: "The RFC3339 time the connection last became active.",
+			Computed:    true,
+		},
+		"error_code": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"error_message": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
@@ -271,6 +454,10 @@ func GetBaseDataSourceConnectionSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		"account_href": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 		"description": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -345,10 +532,22 @@ func GetBaseDataSourceConnectionSchema() map[string]*schema.Schema {
 	}
 }
 
+// GatewayHref returns the HREF used to reference the gateway in the Pureport API
+func GatewayHref(id string) string {
+
+	if id == "" {
+		return ""
+	}
+
+	return "/gateways/" + id
+}
+
 // FlattenGateway flattens the provide gateway to a map for use with terraform
 func FlattenStandardGateway(gateway *client.StandardGateway) (out map[string]interface{}) {
 
 	out = map[string]interface{}{
+		"id":                  gateway.Id,
+		"href":                GatewayHref(gateway.Id),
 		"availability_domain": gateway.AvailabilityDomain,
 		"name":                gateway.Name,
 		"description":         gateway.Description,
@@ -361,6 +560,9 @@ func FlattenStandardGateway(gateway *client.StandardGateway) (out map[string]int
 		"bgp_password":        "",
 		"peering_subnet":      "",
 		"public_nat_ip":       "",
+		"state":               gateway.State,
+		"link_state":          gateway.LinkState,
+		"bgp_state":           "",
 	}
 
 	// If we are using BGP, include the confiuration
@@ -372,6 +574,7 @@ func FlattenStandardGateway(gateway *client.StandardGateway) (out map[string]int
 		out["bgp_password"] = gateway.BgpConfig.Password
 		out["peering_subnet"] = gateway.BgpConfig.PeeringSubnet
 		out["public_nat_ip"] = gateway.BgpConfig.PublicNatIp
+		out["bgp_state"] = gateway.BgpConfig.State
 	}
 
 	return
@@ -381,6 +584,8 @@ func FlattenStandardGateway(gateway *client.StandardGateway) (out map[string]int
 func FlattenVpnGateway(gateway *client.VpnGateway) (out map[string]interface{}) {
 
 	out = map[string]interface{}{
+		"id":                  gateway.Id,
+		"href":                GatewayHref(gateway.Id),
 		"availability_domain": gateway.AvailabilityDomain,
 		"name":                gateway.Name,
 		"description":         gateway.Description,
@@ -388,8 +593,9 @@ func FlattenVpnGateway(gateway *client.VpnGateway) (out map[string]interface{})
 		"customer_vti_ip":     gateway.CustomerVtiIP,
 		"pureport_gateway_ip": gateway.PureportGatewayIP,
 		"pureport_vti_ip":     gateway.PureportVtiIP,
-		"vpn_auth_type":       gateway.Auth.Type_,
-		"vpn_auth_key":        gateway.Auth.Key,
+		"vpn_auth_type":       "",
+		"vpn_auth_key":        "",
+		"ipsec_status":        gateway.IpsecStatus,
 		"customer_asn":        0,
 		"customer_ip":         "",
 		"pureport_asn":        0,
@@ -397,6 +603,14 @@ func FlattenVpnGateway(gateway *client.VpnGateway) (out map[string]interface{})
 		"bgp_password":        "",
 		"peering_subnet":      "",
 		"public_nat_ip":       "",
+		"state":               gateway.State,
+		"link_state":          gateway.LinkState,
+		"bgp_state":           "",
+	}
+
+	if gateway.Auth != nil {
+		out["vpn_auth_type"] = gateway.Auth.Type_
+		out["vpn_auth_key"] = gateway.Auth.Key
 	}
 
 	// If we are using BGP, include the confiuration
@@ -408,11 +622,53 @@ func FlattenVpnGateway(gateway *client.VpnGateway) (out map[string]interface{})
 		out["bgp_password"] = gateway.BgpConfig.Password
 		out["peering_subnet"] = gateway.BgpConfig.PeeringSubnet
 		out["public_nat_ip"] = gateway.BgpConfig.PublicNatIp
+		out["bgp_state"] = gateway.BgpConfig.State
 	}
 
 	return
 }
 
+// NormalizeCIDR returns the canonical form of a CIDR block, as returned by the
+// Pureport API, e.g. with the host bits cleared. Invalid values are returned as is.
+func NormalizeCIDR(cidr string) string {
+
+	_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return cidr
+	}
+
+	return network.String()
+}
+
+// SuppressEquivalentCIDRDiffs suppresses diffs between CIDR blocks that only
+// differ in formatting.
+func SuppressEquivalentCIDRDiffs(k, old, new string, d *schema.ResourceData) bool {
+	return NormalizeCIDR(old) == NormalizeCIDR(new)
+}
+
+// customerNetworkName returns the name of the customer network, defaulting to its
+// CIDR block when no name is specified.
+func customerNetworkName(name string, address string) string {
+
+	if name == "" {
+		return NormalizeCIDR(address)
+	}
+
+	return name
+}
+
+func hashCustomerNetwork(v interface{}) int {
+	m := v.(map[string]interface{})
+	address := m["address"].(string)
+	name, _ := m["name"].(string)
+	return hashcode.String(fmt.Sprintf("%s-%s", customerNetworkName(name, address), NormalizeCIDR(address)))
+}
+
+func hashNatMapping(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(NormalizeCIDR(m["native_cidr"].(string)))
+}
+
 func FlattenCustomerNetworks(customerNetworks []client.CustomerNetwork) (out []map[string]string) {
 
 	for _, cn := range customerNetworks {
@@ -450,11 +706,88 @@ func flattenMappings(mappings []client.NatMapping) (out []map[string]interface{}
 	return
 }
 
-func WaitForConnection(name string, d *schema.ResourceData, m interface{}) error {
+// GetConnectionError returns the error reported for a connection. When the
+// connection itself doesn't report an error, the first gateway error is used.
+func GetConnectionError(c interface{}) (code string, message string) {
+
+	conn := reflect.Indirect(reflect.ValueOf(c))
+	if conn.Kind() != reflect.Struct {
+		return
+	}
+
+	code = conn.FieldByName("ErrorCode").String()
+	message = conn.FieldByName("ErrorMessage").String()
+
+	if code != "" || message != "" {
+		return
+	}
+
+	for _, field := range []string{"PrimaryGateway", "SecondaryGateway"} {
+
+		gateway := conn.FieldByName(field)
+		if !gateway.IsValid() || gateway.IsNil() {
+			continue
+		}
+
+		gateway = gateway.Elem()
+		code = gateway.FieldByName("ErrorCode").String()
+		message = gateway.FieldByName("ErrorMessage").String()
+
+		if code != "" || message != "" {
+			return
+		}
+	}
+
+	return
+}
+
+// WaitForConnection waits for the connection of the resource to become active,
+// recording the error on the resource when it fails to provision. The wait is
+// bounded by the timeout of the resource for the key, e.g. schema.TimeoutUpdate.
+// Nothing is waited for when wait_for_provisioning is disabled.
+func WaitForConnection(name string, d *schema.ResourceData, m interface{}, key string) error {
+
+	if !d.Get("wait_for_provisioning").(bool) {
+		log.Printf("[Info] Not waiting for %s %s to become active, wait_for_provisioning is disabled", name, d.Id())
+		return nil
+	}
 
 	config := m.(*configuration.Config)
+
+	_, err := waitForConnectionActive(config, name, d.Id(), d.Timeout(key), GetSimulation(d, key))
+	if err != nil {
+		if perr, ok := err.(*ProvisioningError); ok {
+			d.Set("error_code", perr.Code)
+			d.Set("error_message", perr.Message)
+		}
+		return err
+	}
+
+	if waitForBGP, ok := d.GetOk("wait_for_bgp"); ok && waitForBGP.(bool) {
+		return WaitForBGP(name, d, m, key)
+	}
+
+	return nil
+}
+
+// WaitForConnectionActive waits for the connection to become active and returns it.
+// It doesn't depend on the Terraform resource, so it can be used by other tools.
+// A *ProvisioningError is returned when the connection fails to provision.
+func WaitForConnectionActive(config *configuration.Config, name string, connectionId string, timeout time.Duration) (interface{}, error) {
+	return waitForConnectionActive(config, name, connectionId, timeout, nil)
+}
+
+// waitForConnectionActive waits for the connection to become active, applying the
+// simulated provisioning when it isn't nil.
+func waitForConnectionActive(config *configuration.Config, name string, connectionId string, timeout time.Duration, sim *Simulation) (interface{}, error) {
+
 	ctx := config.Session.GetSessionContext()
-	connectionId := d.Id()
+	deadline := time.Now().Add(timeout)
+
+	stopCtx := config.StopContext
+	if stopCtx == nil {
+		stopCtx = context.Background()
+	}
 
 	log.Printf("[Info] Waiting for connection to come up.")
 
@@ -472,28 +805,552 @@ func WaitForConnection(name string, d *schema.ResourceData, m interface{}) error
 
 			c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
 			if err != nil {
-				return 0, "", fmt.Errorf("Error reading data for %s: %s", name, err)
+				return 0, "", fmt.Errorf("Error reading data for %s: %s", name, configuration.APIError(err))
 			}
 
 			if resp.StatusCode >= 300 {
-				return 0, "", fmt.Errorf("Error received while waiting for creation of %s: code=%v", name, resp.StatusCode)
+				return 0, "", fmt.Errorf("Error received while waiting for %s to become active: code=%v", name, resp.StatusCode)
+			}
+
+			state := ConnectionState(c)
+
+			if strings.HasPrefix(state, "FAILED") {
+				code, message := GetConnectionError(c)
+
+				return c, state, &ProvisioningError{
+					Name:    name,
+					State:   state,
+					Code:    code,
+					Message: message,
+				}
 			}
 
-			conn := reflect.ValueOf(c)
-			state := conn.FieldByName("State").String()
+			if sim != nil && state == "ACTIVE" {
+				simState, err := sim.refresh(stopCtx, name, deadline)
+				if simState != "" || err != nil {
+					return c, simState, err
+				}
+			}
 
 			return c, state, nil
 
 		},
-		Timeout:                   d.Timeout(schema.TimeoutCreate),
-		Delay:                     5 * time.Second,
+		Timeout:                   timeout,
+		Delay:                     config.GetPollDelay(),
+		PollInterval:              config.PollInterval,
 		MinTimeout:                5 * time.Second,
 		ContinuousTargetOccurence: 2,
 	}
 
-	_, err := createStateConf.WaitForState()
+	c, err := createStateConf.WaitForState()
 	if err != nil {
-		return fmt.Errorf("Error waiting for connection (%s) to be created: %s", connectionId, err)
+		if perr, ok := err.(*ProvisioningError); ok {
+			return c, perr
+		}
+		return c, fmt.Errorf("Error waiting for connection (%s) to become active: %s", connectionId, err)
+	}
+
+	return c, nil
+}
+
+// RetryableErrorCodes are the fragments of API error codes for failures that
+// are expected to succeed when the connection is provisioned again.
+var RetryableErrorCodes = []string{
+	"TIMEOUT",
+	"TEMPORARY",
+	"TRANSIENT",
+	"UNAVAILABLE",
+	"CAPACITY",
+	"INTERNAL",
+}
+
+// ProvisioningError is returned when a connection enters a failed state while
+// waiting for it to become active.
+type ProvisioningError struct {
+	Name    string
+	State   string
+	Code    string
+	Message string
+}
+
+func (e *ProvisioningError) Error() string {
+	return fmt.Sprintf("%s entered state %s: code=%s, message=%s", e.Name, e.State, e.Code, e.Message)
+}
+
+// Retryable returns whether the failure is expected to be transient
+func (e *ProvisioningError) Retryable() bool {
+
+	code := strings.ToUpper(e.Code)
+	for _, fragment := range RetryableErrorCodes {
+		if code != "" && strings.Contains(code, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateHighAvailabilityDowngrade prevents high availability from being
+// disabled on an existing connection unless allow_ha_downgrade is set.
+func ValidateHighAvailabilityDowngrade(d *schema.ResourceDiff, m interface{}) error {
+
+	if d.Id() == "" || !d.HasChange("high_availability") {
+		return nil
+	}
+
+	o, n := d.GetChange("high_availability")
+	if !o.(bool) || n.(bool) {
+		return nil
+	}
+
+	if d.Get("allow_ha_downgrade").(bool) {
+		log.Printf("[Info] Disabling high availability for connection %s", d.Id())
+		return nil
+	}
+
+	return fmt.Errorf("Disabling high_availability for connection %s removes its redundant gateway. Set allow_ha_downgrade = true to confirm the change.", d.Id())
+}
+
+// SupportedSpeeds returns the sorted speeds supported for the connection type at the location.
+func SupportedSpeeds(supported []client.SupportedConnection, connectionType string, locationHref string) (speeds []int) {
+
+	found := make(map[int]bool)
+	for _, s := range supported {
+
+		if s.Type_ != connectionType || s.Location == nil || s.Location.Href != locationHref {
+			continue
+		}
+
+		if !found[int(s.Speed)] {
+			found[int(s.Speed)] = true
+			speeds = append(speeds, int(s.Speed))
+		}
+	}
+
+	sort.Ints(speeds)
+
+	return
+}
+
+// SupportedBillingTerms returns the billing terms offered for the connection type,
+// speed and high availability at the location, in ascending order.
+func SupportedBillingTerms(supported []client.SupportedConnection, connectionType string, locationHref string, speed int, highAvailability bool) (terms []string) {
+
+	found := make(map[string]bool)
+	for _, s := range supported {
+
+		if s.Type_ != connectionType || s.Location == nil || s.Location.Href != locationHref {
+			continue
+		}
+
+		if int(s.Speed) != speed || s.HighAvailability != highAvailability {
+			continue
+		}
+
+		for _, plan := range s.BillingPlans {
+			if plan.Term != "" && !found[plan.Term] {
+				found[plan.Term] = true
+				terms = append(terms, plan.Term)
+			}
+		}
+	}
+
+	sort.Strings(terms)
+
+	return
+}
+
+// getSupportedConnections returns the connections supported for the account of the
+// network the connection is planned in.
+func getSupportedConnections(d *schema.ResourceDiff, m interface{}) ([]client.SupportedConnection, error) {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	networkId := filepath.Base(d.Get("network_href").(string))
+	network, resp, err := config.Session.Client.NetworksApi.GetNetwork(ctx, networkId)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading network %s: %s", networkId, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 || network.Account == nil {
+		return nil, fmt.Errorf("Unable to find the account for network %s", networkId)
+	}
+
+	return config.GetSupportedConnections(filepath.Base(network.Account.Href))
+}
+
+// ValidateSpeed returns a CustomizeDiffFunc that validates the speed of a connection
+// against the speeds the Pureport API supports for the connection type at its location.
+// When the supported speeds aren't known during the plan, e.g. because the network
+// is created in the same apply, the speed is checked against the ValidSpeeds instead.
+func ValidateSpeed(connectionType string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+
+		if !d.NewValueKnown("speed") {
+			return nil
+		}
+
+		if d.Id() != "" && !d.HasChange("speed") {
+			return nil
+		}
+
+		speed := GetSpeed(d)
+
+		if !d.NewValueKnown("location_href") || !d.NewValueKnown("network_href") {
+			return checkValidSpeed(speed)
+		}
+
+		supported, err := getSupportedConnections(d, m)
+		if err != nil {
+			log.Printf("[Info] Unable to read supported connections, validating the speed against the speeds offered by Pureport: %s", err)
+			return checkValidSpeed(speed)
+		}
+
+		speeds := SupportedSpeeds(supported, connectionType, d.Get("location_href").(string))
+		if len(speeds) == 0 {
+			log.Printf("[Info] No supported %s connections found at %s, validating the speed against the speeds offered by Pureport", connectionType, d.Get("location_href"))
+			return checkValidSpeed(speed)
+		}
+
+		for _, s := range speeds {
+			if s == speed {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Speed %d is not supported for %s connections at %s. Supported speeds are %v", speed, connectionType, d.Get("location_href"), speeds)
+	}
+}
+
+// ValidateBillingTerm returns a CustomizeDiffFunc that checks the billing term of
+// a new connection, or a change to the billing term of an existing connection, is
+// offered for the connection at its location.
+func ValidateBillingTerm(connectionType string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+
+		keys := []string{"speed", "location_href", "network_href", "billing_term", "high_availability"}
+
+		for _, k := range keys {
+			if !d.NewValueKnown(k) {
+				return nil
+			}
+		}
+
+		if d.Id() != "" && !d.HasChange("billing_term") {
+			return nil
+		}
+
+		supported, err := getSupportedConnections(d, m)
+		if err != nil {
+			log.Printf("[Info] Unable to read supported connections, skipping billing term validation: %s", err)
+			return nil
+		}
+
+		term := d.Get("billing_term").(string)
+		speed := GetSpeed(d)
+		terms := SupportedBillingTerms(supported, connectionType, d.Get("location_href").(string), speed, d.Get("high_availability").(bool))
+		if len(terms) == 0 {
+			log.Printf("[Info] No billing plans found for %s connections at %s, skipping billing term validation", connectionType, d.Get("location_href"))
+			return nil
+		}
+
+		for _, t := range terms {
+			if t == term {
+				return nil
+			}
+		}
+
+		if d.Id() != "" {
+			old, _ := d.GetChange("billing_term")
+			return fmt.Errorf("Billing term can't be changed from %s to %s for %s connections of speed %d at %s. Available billing terms are %v",
+				old, term, connectionType, speed, d.Get("location_href"), terms)
+		}
+
+		return fmt.Errorf("Billing term %s is not available for %s connections of speed %d at %s. Available billing terms are %v",
+			term, connectionType, speed, d.Get("location_href"), terms)
+	}
+}
+
+// FindConnectionByName returns the connection in the network with the same name
+// as the connection being managed, or nil when there isn't one.
+func FindConnectionByName(d *schema.ResourceData, m interface{}) (*client.Connection, error) {
+
+	networkId := filepath.Base(d.Get("network_href").(string))
+
+	return findConnection(m, networkId, d.Get("name").(string))
+}
+
+// findConnection returns the connection in the network with the given name, or
+// nil when there isn't one.
+func findConnection(m interface{}, networkId string, connectionName string) (*client.Connection, error) {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	connections, resp, err := config.Session.Client.ConnectionsApi.GetConnections(ctx, networkId)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for existing connections: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Error Response while checking for existing connections: code=%v", resp.StatusCode)
+	}
+
+	for _, c := range connections {
+		if c.Name == connectionName {
+			return &c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// CheckNetworkActive returns an error naming the network when the network the
+// connection is added to does not exist or is not active.
+func CheckNetworkActive(name string, d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	networkHref := d.Get("network_href").(string)
+	networkId := filepath.Base(networkHref)
+
+	network, resp, err := config.Session.Client.NetworksApi.GetNetwork(ctx, networkId)
+	if IsNotFound(resp) {
+		return fmt.Errorf("Unable to create %s: network %s does not exist. Please check the network_href.", name, networkHref)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error reading network %s for %s: %s", networkHref, name, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while reading network %s for %s: code=%v", networkHref, name, resp.StatusCode)
+	}
+
+	if network.State != "" && network.State != "ACTIVE" {
+		return fmt.Errorf("Unable to create %s: network %s (%s) is %s, not ACTIVE.", name, network.Name, networkHref, network.State)
+	}
+
+	return nil
+}
+
+// CheckConnectionNameAvailable returns an error asking for the existing connection
+// to be imported when a connection with the same name already exists in the network.
+func CheckConnectionNameAvailable(name string, d *schema.ResourceData, m interface{}) error {
+
+	c, err := FindConnectionByName(d, m)
+	if err != nil {
+		return err
+	}
+
+	if c != nil {
+		networkId := filepath.Base(d.Get("network_href").(string))
+		return fmt.Errorf("A connection with the name %q already exists in network %s (%s) - to be managed via Terraform this %s needs to be imported into the State. Please see the resource documentation for more information.", c.Name, networkId, c.Id, name)
+	}
+
+	return nil
+}
+
+// CreateRetries is the number of times a create request that fails with a
+// transient error is retried.
+const CreateRetries = 3
+
+// CreateRetryDelay is the delay before the first retry of a create request. It
+// increases with each attempt.
+var CreateRetryDelay = 5 * time.Second
+
+// TransientError is returned for failed requests that may succeed when retried,
+// e.g. server side errors or dropped connections.
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+// IsTransientResponse returns whether a failed request may succeed when retried.
+func IsTransientResponse(resp *http.Response) bool {
+	return resp == nil || resp.StatusCode >= 500
+}
+
+// IsNotFound returns whether the request failed because the object no longer exists.
+func IsNotFound(resp *http.Response) bool {
+	return resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone)
+}
+
+// CreateError returns the error for a failed request to create a connection.
+// Transient failures are returned as a TransientError so they can be retried.
+func CreateError(name string, resp *http.Response, err error) error {
+
+	e := fmt.Errorf("Error while creating %s: err=%s", name, configuration.APIError(err))
+	if IsTransientResponse(resp) {
+		return &TransientError{Err: e}
+	}
+
+	return e
+}
+
+// addConnection adds the connection using the provided function, retrying transient
+// failures. Since a failed request may still have created the connection, the network
+// is checked for it before each retry so retries don't create duplicate connections.
+func addConnection(name string, d *schema.ResourceData, m interface{}, add func(*schema.ResourceData, interface{}) error) error {
+
+	for attempt := 0; ; attempt++ {
+
+		err := add(d, m)
+		if err == nil {
+			return nil
+		}
+
+		if _, ok := err.(*TransientError); !ok || attempt >= CreateRetries {
+			return err
+		}
+
+		existing, ferr := FindConnectionByName(d, m)
+		if ferr != nil {
+			log.Printf("[Info] Unable to check whether %s was created: %s", name, ferr)
+			return err
+		}
+
+		if existing != nil {
+			log.Printf("[Info] %s %s was created by a request that failed: %s", name, existing.Id, err)
+			d.SetId(existing.Id)
+			return nil
+		}
+
+		log.Printf("[Info] Failed to create %s with a transient error, retrying (%d/%d): %s", name, attempt+1, CreateRetries, err)
+		time.Sleep(time.Duration(attempt+1) * CreateRetryDelay)
+	}
+}
+
+// adoptConnection uses an existing connection with the same name in the network
+// when adopt_existing is set. It returns whether a connection was adopted.
+func adoptConnection(name string, d *schema.ResourceData, m interface{}) (bool, error) {
+
+	if !d.Get("adopt_existing").(bool) {
+		return false, nil
+	}
+
+	existing, err := FindConnectionByName(d, m)
+	if err != nil || existing == nil {
+		return false, err
+	}
+
+	if t, ok := connectionTypes[name]; ok && existing.Type_ != t {
+		return false, fmt.Errorf("Unable to adopt connection %s (%s): it is a %s connection, not a %s", existing.Name, existing.Id, existing.Type_, t)
+	}
+
+	log.Printf("[Info] Adopting existing %s %s (%s)", name, existing.Name, existing.Id)
+	d.SetId(existing.Id)
+
+	return true, nil
+}
+
+// ProvisionConnection adds a new connection using the provided function and waits
+// for it to become active. When the connection fails to provision with a retryable
+// error, it is deleted and added again up to provisioning_retries times.
+func ProvisionConnection(name string, d *schema.ResourceData, m interface{}, add func(*schema.ResourceData, interface{}) error) error {
+
+	if err := CheckNetworkActive(name, d, m); err != nil {
+		return err
+	}
+
+	adopted, err := adoptConnection(name, d, m)
+	if err != nil {
+		return err
+	}
+
+	if adopted {
+		if err := WaitForConnection(name, d, m, schema.TimeoutCreate); err != nil {
+			return fmt.Errorf("Error waiting for %s: err=%s", name, err)
+		}
+		return nil
+	}
+
+	if err := CheckConnectionNameAvailable(name, d, m); err != nil {
+		return err
+	}
+
+	retries := 0
+	if v, ok := d.GetOk("provisioning_retries"); ok {
+		retries = v.(int)
+	}
+
+	for attempt := 0; ; attempt++ {
+
+		if err := addConnection(name, d, m, add); err != nil {
+			return err
+		}
+
+		err := WaitForConnection(name, d, m, schema.TimeoutCreate)
+		if err == nil {
+			return nil
+		}
+
+		perr, ok := err.(*ProvisioningError)
+		if !ok || !perr.Retryable() || attempt >= retries {
+			return fmt.Errorf("Error waiting for %s: err=%s", name, err)
+		}
+
+		log.Printf("[Info] %s failed to provision with retryable error %s, retrying (%d/%d)", name, perr.Code, attempt+1, retries)
+
+		if err := deleteConnection(name, d, m); err != nil {
+			return fmt.Errorf("Error removing failed %s before retrying: %s", name, err)
+		}
+	}
+}
+
+// WaitForBGP waits until the BGP sessions for all of the connection gateways have
+// been established, bounded by the timeout of the resource for the key. Gateways
+// that don't use BGP are ignored.
+func WaitForBGP(name string, d *schema.ResourceData, m interface{}, key string) error {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+	connectionId := d.Id()
+
+	log.Printf("[Info] Waiting for BGP sessions to be established.")
+
+	bgpStateConf := &resource.StateChangeConf{
+		Pending: []string{
+			"WAITING",
+		},
+		Target: []string{
+			"ESTABLISHED",
+		},
+		Refresh: func() (interface{}, string, error) {
+
+			c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
+			if err != nil {
+				return 0, "", fmt.Errorf("Error reading data for %s: %s", name, configuration.APIError(err))
+			}
+
+			if resp.StatusCode >= 300 {
+				return 0, "", fmt.Errorf("Error received while waiting for BGP of %s: code=%v", name, resp.StatusCode)
+			}
+
+			for _, g := range GetGatewayStatuses(c) {
+				if g.BgpState != "" && g.BgpState != "ESTABLISHED" {
+					log.Printf("[Info] BGP session for gateway %s is %s", g.Name, g.BgpState)
+					return c, "WAITING", nil
+				}
+			}
+
+			return c, "ESTABLISHED", nil
+		},
+		Timeout:                   d.Timeout(key),
+		Delay:                     config.GetPollDelay(),
+		PollInterval:              config.PollInterval,
+		MinTimeout:                5 * time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+
+	_, err := bgpStateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for BGP sessions of connection (%s) to be established: %s", connectionId, err)
 	}
 
 	return nil
@@ -501,6 +1358,16 @@ func WaitForConnection(name string, d *schema.ResourceData, m interface{}) error
 
 func DeleteConnection(name string, d *schema.ResourceData, m interface{}) error {
 
+	if protected, ok := d.GetOk("deletion_protection"); ok && protected.(bool) {
+		return fmt.Errorf("Error deleting %s (%s): deletion_protection is enabled. Set deletion_protection to false and apply before deleting the connection.", name, d.Id())
+	}
+
+	return deleteConnection(name, d, m)
+}
+
+// deleteConnection deletes the connection regardless of deletion protection
+func deleteConnection(name string, d *schema.ResourceData, m interface{}) error {
+
 	config := m.(*configuration.Config)
 	ctx := config.Session.GetSessionContext()
 	connectionId := d.Id()
@@ -527,22 +1394,26 @@ func DeleteConnection(name string, d *schema.ResourceData, m interface{}) error
 		Refresh: func() (interface{}, string, error) {
 
 			c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
+			if IsNotFound(resp) {
+				return 0, "DELETED", nil
+			}
+
 			if err != nil {
-				return 0, "", fmt.Errorf("Error deleting data for %s: %s", name, err)
+				return 0, "", fmt.Errorf("Error deleting data for %s: %s", name, configuration.APIError(err))
 			}
 
 			if resp.StatusCode >= 300 {
 				return 0, "", fmt.Errorf("Error Response while attempting to delete %s: code=%v", name, resp.StatusCode)
 			}
 
-			conn := reflect.ValueOf(c)
-			state := conn.FieldByName("State").String()
+			state := ConnectionState(c)
 
 			return c, state, nil
 
 		},
 		Timeout:                   d.Timeout(schema.TimeoutDelete),
-		Delay:                     5 * time.Second,
+		Delay:                     config.GetPollDelay(),
+		PollInterval:              config.PollInterval,
 		MinTimeout:                1 * time.Second,
 		ContinuousTargetOccurence: 2,
 	}
@@ -554,8 +1425,14 @@ func DeleteConnection(name string, d *schema.ResourceData, m interface{}) error
 
 	// Delete
 	_, resp, err := config.Session.Client.ConnectionsApi.DeleteConnection(ctx, connectionId)
+	if IsNotFound(resp) {
+		log.Printf("[Info] %s %s already deleted", name, connectionId)
+		d.SetId("")
+		return nil
+	}
+
 	if err != nil {
-		return fmt.Errorf("Error deleting data for %s: %s", name, err)
+		return fmt.Errorf("Error deleting data for %s: %s", name, configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -579,22 +1456,22 @@ func DeleteConnection(name string, d *schema.ResourceData, m interface{}) error
 
 			c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
 
-			if resp.StatusCode == 404 {
+			if IsNotFound(resp) {
 				return 0, "DELETED", nil
 			}
 
 			if err != nil {
-				return 0, "", fmt.Errorf("Error Response while deleting %s: error=%s", name, err)
+				return 0, "", fmt.Errorf("Error Response while deleting %s: error=%s", name, configuration.APIError(err))
 			}
 
-			conn := reflect.ValueOf(c)
-			state := conn.FieldByName("State").String()
+			state := ConnectionState(c)
 
 			return c, state, nil
 
 		},
 		Timeout:                   d.Timeout(schema.TimeoutDelete),
-		Delay:                     5 * time.Second,
+		Delay:                     config.GetPollDelay(),
+		PollInterval:              config.PollInterval,
 		MinTimeout:                1 * time.Second,
 		ContinuousTargetOccurence: 2,
 	}
@@ -621,7 +1498,7 @@ func ExpandCustomerNetworks(d *schema.ResourceData) []client.CustomerNetwork {
 			network := cn.(map[string]interface{})
 
 			new := client.CustomerNetwork{
-				Name:    network["name"].(string),
+				Name:    customerNetworkName(network["name"].(string), network["address"].(string)),
 				Address: network["address"].(string),
 			}
 
@@ -654,6 +1531,11 @@ func ExpandNATConfiguration(d *schema.ResourceData) *client.NatConfig {
 
 			natConfig.Mappings = append(natConfig.Mappings, new)
 		}
+
+		for _, b := range config["blocks"].([]interface{}) {
+			natConfig.Blocks = append(natConfig.Blocks, b.(string))
+		}
+
 		return natConfig
 	}
 
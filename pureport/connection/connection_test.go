@@ -0,0 +1,202 @@
+package connection
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+func TestGetConnectionError(t *testing.T) {
+
+	cases := []struct {
+		Name            string
+		Conn            interface{}
+		ExpectedCode    string
+		ExpectedMessage string
+	}{
+		{
+			Name: "connection error",
+			Conn: client.AwsDirectConnectConnection{
+				ErrorCode:    "PROVISIONING_FAILED",
+				ErrorMessage: "Unable to provision",
+			},
+			ExpectedCode:    "PROVISIONING_FAILED",
+			ExpectedMessage: "Unable to provision",
+		},
+		{
+			Name: "gateway error",
+			Conn: client.SiteIpSecVpnConnection{
+				PrimaryGateway:   &client.VpnGateway{},
+				SecondaryGateway: &client.VpnGateway{ErrorCode: "IKE_FAILED", ErrorMessage: "IKE negotiation failed"},
+			},
+			ExpectedCode:    "IKE_FAILED",
+			ExpectedMessage: "IKE negotiation failed",
+		},
+		{
+			Name: "no error",
+			Conn: client.AzureExpressRouteConnection{},
+		},
+	}
+
+	for _, c := range cases {
+		code, message := GetConnectionError(c.Conn)
+		if code != c.ExpectedCode || message != c.ExpectedMessage {
+			t.Errorf("%s: expected (%s, %s), got (%s, %s)", c.Name, c.ExpectedCode, c.ExpectedMessage, code, message)
+		}
+	}
+}
+
+func TestProvisioningErrorRetryable(t *testing.T) {
+
+	cases := []struct {
+		Code     string
+		Expected bool
+	}{
+		{Code: "PROVISIONING_TIMEOUT", Expected: true},
+		{Code: "cloud_provider_unavailable", Expected: true},
+		{Code: "INVALID_ACCOUNT", Expected: false},
+		{Code: "", Expected: false},
+	}
+
+	for _, c := range cases {
+		err := &ProvisioningError{Name: "Test Connection", State: "FAILED_TO_PROVISION", Code: c.Code}
+		if actual := err.Retryable(); actual != c.Expected {
+			t.Errorf("%s: expected %t, got %t", c.Code, c.Expected, actual)
+		}
+	}
+}
+
+func TestSupportedSpeeds(t *testing.T) {
+
+	supported := []client.SupportedConnection{
+		{Type_: "AWS_DIRECT_CONNECT", Speed: 1000, Location: &client.Link{Href: "/locations/us-sea"}},
+		{Type_: "AWS_DIRECT_CONNECT", Speed: 50, Location: &client.Link{Href: "/locations/us-sea"}},
+		{Type_: "AWS_DIRECT_CONNECT", Speed: 50, Location: &client.Link{Href: "/locations/us-sea"}, HighAvailability: true},
+		{Type_: "AWS_DIRECT_CONNECT", Speed: 100, Location: &client.Link{Href: "/locations/us-ral"}},
+		{Type_: "SITE_IPSEC_VPN", Speed: 200, Location: &client.Link{Href: "/locations/us-sea"}},
+		{Type_: "SITE_IPSEC_VPN", Speed: 500},
+	}
+
+	cases := []struct {
+		Type     string
+		Location string
+		Expected []int
+	}{
+		{Type: "AWS_DIRECT_CONNECT", Location: "/locations/us-sea", Expected: []int{50, 1000}},
+		{Type: "AWS_DIRECT_CONNECT", Location: "/locations/us-ral", Expected: []int{100}},
+		{Type: "SITE_IPSEC_VPN", Location: "/locations/us-sea", Expected: []int{200}},
+		{Type: "GOOGLE_CLOUD_INTERCONNECT", Location: "/locations/us-sea", Expected: nil},
+	}
+
+	for _, c := range cases {
+		speeds := SupportedSpeeds(supported, c.Type, c.Location)
+		if !reflect.DeepEqual(speeds, c.Expected) {
+			t.Errorf("%s at %s: expected %v, got %v", c.Type, c.Location, c.Expected, speeds)
+		}
+	}
+}
+
+func TestSupportedBillingTerms(t *testing.T) {
+
+	plans := []client.BillingPlan{
+		{Term: "MONTHLY", BillingInterval: "MONTH"},
+		{Term: "HOURLY", BillingInterval: "HOUR"},
+	}
+
+	supported := []client.SupportedConnection{
+		{Type_: "AWS_DIRECT_CONNECT", Speed: 50, Location: &client.Link{Href: "/locations/us-sea"}, BillingPlans: plans},
+		{Type_: "AWS_DIRECT_CONNECT", Speed: 50, Location: &client.Link{Href: "/locations/us-sea"}, HighAvailability: true, BillingPlans: plans[1:]},
+		{Type_: "AWS_DIRECT_CONNECT", Speed: 100, Location: &client.Link{Href: "/locations/us-sea"}, BillingPlans: plans[:1]},
+		{Type_: "SITE_IPSEC_VPN", Speed: 50, Location: &client.Link{Href: "/locations/us-sea"}},
+	}
+
+	cases := []struct {
+		Type             string
+		Speed            int
+		HighAvailability bool
+		Expected         []string
+	}{
+		{Type: "AWS_DIRECT_CONNECT", Speed: 50, Expected: []string{"HOURLY", "MONTHLY"}},
+		{Type: "AWS_DIRECT_CONNECT", Speed: 50, HighAvailability: true, Expected: []string{"HOURLY"}},
+		{Type: "AWS_DIRECT_CONNECT", Speed: 100, Expected: []string{"MONTHLY"}},
+		{Type: "AWS_DIRECT_CONNECT", Speed: 200, Expected: nil},
+		{Type: "SITE_IPSEC_VPN", Speed: 50, Expected: nil},
+	}
+
+	for _, c := range cases {
+		terms := SupportedBillingTerms(supported, c.Type, "/locations/us-sea", c.Speed, c.HighAvailability)
+		if !reflect.DeepEqual(terms, c.Expected) {
+			t.Errorf("%s %d (HA %v): expected %v, got %v", c.Type, c.Speed, c.HighAvailability, c.Expected, terms)
+		}
+	}
+}
+
+func TestCreateError(t *testing.T) {
+
+	cases := []struct {
+		Name      string
+		Resp      *http.Response
+		Transient bool
+	}{
+		{Name: "no response", Resp: nil, Transient: true},
+		{Name: "server error", Resp: &http.Response{StatusCode: 503}, Transient: true},
+		{Name: "bad request", Resp: &http.Response{StatusCode: 400}, Transient: false},
+	}
+
+	for _, c := range cases {
+		err := CreateError(AwsConnectionName, c.Resp, fmt.Errorf("request failed"))
+		if _, ok := err.(*TransientError); ok != c.Transient {
+			t.Errorf("%s: expected transient=%t, got %T", c.Name, c.Transient, err)
+		}
+
+		expected := "Error while creating AWS Cloud Connection: err=request failed"
+		if err.Error() != expected {
+			t.Errorf("%s: expected %q, got %q", c.Name, expected, err.Error())
+		}
+	}
+}
+
+func TestNormalizeCIDR(t *testing.T) {
+
+	cases := []struct {
+		Input    string
+		Expected string
+	}{
+		{Input: "10.0.0.0/24", Expected: "10.0.0.0/24"},
+		{Input: "10.0.0.1/24", Expected: "10.0.0.0/24"},
+		{Input: " 192.168.1.0/16 ", Expected: "192.168.0.0/16"},
+		{Input: "2001:DB8::1/64", Expected: "2001:db8::/64"},
+		{Input: "not-a-cidr", Expected: "not-a-cidr"},
+	}
+
+	for _, c := range cases {
+		if out := NormalizeCIDR(c.Input); out != c.Expected {
+			t.Errorf("%q: expected %q, got %q", c.Input, c.Expected, out)
+		}
+	}
+}
+
+func TestHashCustomerNetwork(t *testing.T) {
+
+	a := hashCustomerNetwork(map[string]interface{}{"name": "office", "address": "10.0.0.1/24"})
+	b := hashCustomerNetwork(map[string]interface{}{"name": "office", "address": "10.0.0.0/24"})
+	c := hashCustomerNetwork(map[string]interface{}{"name": "branch", "address": "10.0.0.0/24"})
+
+	if a != b {
+		t.Errorf("expected equivalent customer networks to have the same hash")
+	}
+
+	if b == c {
+		t.Errorf("expected customer networks with different names to have different hashes")
+	}
+
+	d := hashCustomerNetwork(map[string]interface{}{"name": "", "address": "10.0.0.1/24"})
+	e := hashCustomerNetwork(map[string]interface{}{"name": "10.0.0.0/24", "address": "10.0.0.0/24"})
+
+	if d != e {
+		t.Errorf("expected an unnamed customer network to have the same hash as one named after its CIDR block")
+	}
+}
@@ -0,0 +1,93 @@
+package connection
+
+import (
+	"log"
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+// hoursPerMonth is used to convert hourly billing plans to a monthly estimate
+const hoursPerMonth = 730
+
+// EstimateMonthlyCost returns the estimated monthly cost of a connection using the
+// billing plans of the matching supported connection. Amounts are returned in the
+// major unit of the billing currency, e.g. dollars rather than cents.
+func EstimateMonthlyCost(supported []client.SupportedConnection, connectionType string, locationHref string, speed int, highAvailability bool, term string) (float64, bool) {
+
+	for _, s := range supported {
+
+		if s.Type_ != connectionType || s.Location == nil || s.Location.Href != locationHref {
+			continue
+		}
+
+		if int(s.Speed) != speed || s.HighAvailability != highAvailability {
+			continue
+		}
+
+		for _, plan := range s.BillingPlans {
+
+			if plan.Term != term {
+				continue
+			}
+
+			amount := float64(plan.Amount) / 100
+
+			switch plan.BillingInterval {
+			case "HOUR":
+				amount = amount * hoursPerMonth
+			case "YEAR":
+				amount = amount / 12
+			}
+
+			return math.Round(amount*100) / 100, true
+		}
+	}
+
+	return 0, false
+}
+
+// EstimateCost returns a CustomizeDiffFunc that sets the estimated monthly cost of the
+// connection, so the cost impact of speed and billing changes is shown in the plan.
+func EstimateCost(connectionType string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+
+		keys := []string{"speed", "location_href", "network_href", "billing_term", "high_availability"}
+
+		for _, k := range keys {
+			if !d.NewValueKnown(k) {
+				return d.SetNewComputed("estimated_monthly_cost")
+			}
+		}
+
+		if d.Id() != "" && !d.HasChange("speed") && !d.HasChange("billing_term") && !d.HasChange("high_availability") {
+			return nil
+		}
+
+		supported, err := getSupportedConnections(d, m)
+		if err != nil {
+			log.Printf("[Info] Unable to read supported connections, skipping cost estimate: %s", err)
+			return nil
+		}
+
+		cost, ok := EstimateMonthlyCost(
+			supported,
+			connectionType,
+			d.Get("location_href").(string),
+			GetSpeed(d),
+			d.Get("high_availability").(bool),
+			d.Get("billing_term").(string),
+		)
+
+		if !ok {
+			log.Printf("[Info] No billing plan found for the %s connection, skipping cost estimate", connectionType)
+			return nil
+		}
+
+		old, _ := d.GetChange("estimated_monthly_cost")
+		log.Printf("[Info] Estimated monthly cost of %s connection changes from %.2f to %.2f", connectionType, old, cost)
+
+		return d.SetNew("estimated_monthly_cost", cost)
+	}
+}
@@ -0,0 +1,55 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+func TestEstimateMonthlyCost(t *testing.T) {
+
+	supported := []client.SupportedConnection{
+		{
+			Type_:    "AWS_DIRECT_CONNECT",
+			Location: &client.Link{Href: "/locations/us-sea"},
+			Speed:    50,
+			BillingPlans: []client.BillingPlan{
+				{Term: "HOURLY", BillingInterval: "HOUR", Amount: 25},
+				{Term: "ANNUAL", BillingInterval: "YEAR", Amount: 180000},
+			},
+		},
+		{
+			Type_:            "AWS_DIRECT_CONNECT",
+			Location:         &client.Link{Href: "/locations/us-sea"},
+			Speed:            50,
+			HighAvailability: true,
+			BillingPlans: []client.BillingPlan{
+				{Term: "HOURLY", BillingInterval: "MONTH", Amount: 30000},
+			},
+		},
+	}
+
+	cases := []struct {
+		Name             string
+		Speed            int
+		HighAvailability bool
+		Term             string
+		Expected         float64
+		Found            bool
+	}{
+		{Name: "hourly", Speed: 50, Term: "HOURLY", Expected: 182.5, Found: true},
+		{Name: "annual", Speed: 50, Term: "ANNUAL", Expected: 150, Found: true},
+		{Name: "high availability", Speed: 50, HighAvailability: true, Term: "HOURLY", Expected: 300, Found: true},
+		{Name: "unknown term", Speed: 50, Term: "MONTHLY", Found: false},
+		{Name: "unsupported speed", Speed: 100, Term: "HOURLY", Found: false},
+	}
+
+	for _, c := range cases {
+
+		cost, found := EstimateMonthlyCost(supported, "AWS_DIRECT_CONNECT", "/locations/us-sea", c.Speed, c.HighAvailability, c.Term)
+
+		if found != c.Found || cost != c.Expected {
+			t.Errorf("%s: expected (%v, %v), got (%v, %v)", c.Name, c.Expected, c.Found, cost, found)
+		}
+	}
+}
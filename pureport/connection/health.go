@@ -0,0 +1,108 @@
+package connection
+
+import (
+	"reflect"
+)
+
+const (
+	HealthHealthy  = "HEALTHY"
+	HealthDegraded = "DEGRADED"
+	HealthDown     = "DOWN"
+	HealthUnknown  = "UNKNOWN"
+)
+
+// GatewayStatus is the status information reported for a single connection gateway
+type GatewayStatus struct {
+	Id        string
+	Name      string
+	State     string
+	LinkState string
+	BgpState  string
+}
+
+// Up returns whether the gateway link and its BGP session, when used, are both up
+func (g GatewayStatus) Up() bool {
+
+	linkUp := g.LinkState == "" || g.LinkState == "UP"
+	bgpUp := g.BgpState == "" || g.BgpState == "ESTABLISHED"
+
+	return linkUp && bgpUp
+}
+
+// GetGatewayStatuses returns the status of the primary and secondary gateways
+// of any connection type returned by the Pureport API.
+func GetGatewayStatuses(c interface{}) (out []GatewayStatus) {
+
+	conn := reflect.Indirect(reflect.ValueOf(c))
+	if conn.Kind() != reflect.Struct {
+		return
+	}
+
+	for _, field := range []string{"PrimaryGateway", "SecondaryGateway"} {
+
+		gateway := conn.FieldByName(field)
+		if !gateway.IsValid() || gateway.IsNil() {
+			continue
+		}
+
+		gateway = gateway.Elem()
+
+		status := GatewayStatus{
+			Id:        gateway.FieldByName("Id").String(),
+			Name:      gateway.FieldByName("Name").String(),
+			State:     gateway.FieldByName("State").String(),
+			LinkState: gateway.FieldByName("LinkState").String(),
+		}
+
+		if bgp := gateway.FieldByName("BgpConfig"); bgp.IsValid() && !bgp.IsNil() {
+			status.BgpState = bgp.Elem().FieldByName("State").String()
+		}
+
+		out = append(out, status)
+	}
+
+	return
+}
+
+// ConnectionState returns the state of any connection type returned by the
+// Pureport API, or an empty string when it isn't a connection.
+func ConnectionState(c interface{}) string {
+
+	conn := reflect.Indirect(reflect.ValueOf(c))
+	if conn.Kind() != reflect.Struct {
+		return ""
+	}
+
+	return conn.FieldByName("State").String()
+}
+
+// ConnectionHealth aggregates the connection state and the state of each of its
+// gateways into a single health status.
+func ConnectionHealth(c interface{}) string {
+
+	switch ConnectionState(c) {
+	case "ACTIVE":
+	case "DOWN":
+		return HealthDown
+	default:
+		return HealthUnknown
+	}
+
+	gateways := GetGatewayStatuses(c)
+
+	up := 0
+	for _, g := range gateways {
+		if g.Up() {
+			up++
+		}
+	}
+
+	switch {
+	case up == len(gateways):
+		return HealthHealthy
+	case up == 0:
+		return HealthDown
+	default:
+		return HealthDegraded
+	}
+}
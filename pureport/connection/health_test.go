@@ -0,0 +1,92 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+func TestConnectionState(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Conn     interface{}
+		Expected string
+	}{
+		{
+			Name:     "value",
+			Conn:     client.AwsDirectConnectConnection{State: "ACTIVE"},
+			Expected: "ACTIVE",
+		},
+		{
+			Name:     "pointer",
+			Conn:     &client.SiteIpSecVpnConnection{State: "PROVISIONING"},
+			Expected: "PROVISIONING",
+		},
+		{
+			Name: "nil",
+		},
+		{
+			Name: "not a connection",
+			Conn: "ACTIVE",
+		},
+	}
+
+	for _, c := range cases {
+		if actual := ConnectionState(c.Conn); actual != c.Expected {
+			t.Errorf("%s: expected %q, got %q", c.Name, c.Expected, actual)
+		}
+	}
+}
+
+func TestConnectionHealth(t *testing.T) {
+
+	up := &client.StandardGateway{LinkState: "UP", BgpConfig: &client.BgpConfig{State: "ESTABLISHED"}}
+	down := &client.StandardGateway{LinkState: "UP", BgpConfig: &client.BgpConfig{State: "IDLE"}}
+
+	cases := []struct {
+		Name     string
+		Conn     interface{}
+		Expected string
+	}{
+		{
+			Name:     "provisioning",
+			Conn:     client.AwsDirectConnectConnection{State: "PROVISIONING"},
+			Expected: HealthUnknown,
+		},
+		{
+			Name:     "down",
+			Conn:     client.AwsDirectConnectConnection{State: "DOWN", PrimaryGateway: up},
+			Expected: HealthDown,
+		},
+		{
+			Name:     "healthy",
+			Conn:     client.AwsDirectConnectConnection{State: "ACTIVE", PrimaryGateway: up, SecondaryGateway: up},
+			Expected: HealthHealthy,
+		},
+		{
+			Name:     "degraded",
+			Conn:     client.AwsDirectConnectConnection{State: "ACTIVE", PrimaryGateway: up, SecondaryGateway: down},
+			Expected: HealthDegraded,
+		},
+		{
+			Name:     "all gateways down",
+			Conn:     client.AwsDirectConnectConnection{State: "ACTIVE", PrimaryGateway: down},
+			Expected: HealthDown,
+		},
+		{
+			Name: "vpn without bgp",
+			Conn: client.SiteIpSecVpnConnection{
+				State:          "ACTIVE",
+				PrimaryGateway: &client.VpnGateway{LinkState: "UP"},
+			},
+			Expected: HealthHealthy,
+		},
+	}
+
+	for _, c := range cases {
+		if actual := ConnectionHealth(c.Conn); actual != c.Expected {
+			t.Errorf("%s: expected %s, got %s", c.Name, c.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package connection
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// importNamePrefix marks an import ID that references the connection by name
+const importNamePrefix = "name="
+
+// parseImportName parses an import ID of the form `<network-id>/name=<name>`,
+// returning whether the ID references the connection by name.
+func parseImportName(id string) (networkId string, connectionName string, ok bool) {
+
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], importNamePrefix) {
+		return "", "", false
+	}
+
+	return parts[0], strings.TrimPrefix(parts[1], importNamePrefix), true
+}
+
+// ImportConnection returns a StateFunc that imports a connection by its ID, or by
+// its name within a network using `<network-id>/name=<name>`.
+func ImportConnection(name string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+
+		networkId, connectionName, ok := parseImportName(d.Id())
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if networkId == "" || connectionName == "" {
+			return nil, fmt.Errorf("Invalid import ID %q for %s: expected <network-id>/name=<name>", d.Id(), name)
+		}
+
+		c, err := findConnection(m, networkId, connectionName)
+		if err != nil {
+			return nil, err
+		}
+
+		if c == nil {
+			return nil, fmt.Errorf("Unable to import %s: no connection named %q found in network %s", name, connectionName, networkId)
+		}
+
+		if t, ok := connectionTypes[name]; ok && c.Type_ != t {
+			return nil, fmt.Errorf("Unable to import %s: connection %s (%s) is a %s connection, not a %s", name, c.Name, c.Id, c.Type_, t)
+		}
+
+		log.Printf("[Info] Resolved %s %q in network %s to %s", name, connectionName, networkId, c.Id)
+		d.SetId(c.Id)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,32 @@
+package connection
+
+import (
+	"testing"
+)
+
+func TestParseImportName(t *testing.T) {
+
+	cases := []struct {
+		id             string
+		networkId      string
+		connectionName string
+		ok             bool
+	}{
+		{"conn-abc", "", "", false},
+		{"network-abc/conn-abc", "", "", false},
+		{"network-abc/name=my-conn", "network-abc", "my-conn", true},
+		{"network-abc/name=my/conn", "network-abc", "my/conn", true},
+		{"network-abc/name=", "network-abc", "", true},
+		{"/name=my-conn", "", "my-conn", true},
+	}
+
+	for _, c := range cases {
+
+		networkId, connectionName, ok := parseImportName(c.id)
+
+		if ok != c.ok || networkId != c.networkId || connectionName != c.connectionName {
+			t.Errorf("parseImportName(%q) = (%q, %q, %v), expected (%q, %q, %v)",
+				c.id, networkId, connectionName, ok, c.networkId, c.connectionName, c.ok)
+		}
+	}
+}
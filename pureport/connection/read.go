@@ -0,0 +1,173 @@
+package connection
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+// FormatTime formats a timestamp returned by the Pureport API, returning an
+// empty string for unset times.
+func FormatTime(t time.Time) string {
+
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// ReadConnection gets the connection for the resource. When the connection no
+// longer exists, it is removed from the State and nil is returned.
+func ReadConnection(name string, d *schema.ResourceData, m interface{}) (interface{}, error) {
+
+	config := m.(*configuration.Config)
+	connectionId := d.Id()
+	ctx := config.Session.GetSessionContext()
+
+	c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
+	if err != nil {
+		if IsNotFound(resp) {
+			log.Printf("[Info] %s %s not found, removing it from the State", name, d.Id())
+			d.SetId("")
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Error reading data for %s: %s", name, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Error Response while reading %s: code=%v", name, resp.StatusCode)
+	}
+
+	return c, nil
+}
+
+// ReadConnectionAccount sets the account that owns the network of the connection.
+// Networks can't be moved between accounts, so the network is only read when the
+// account isn't known yet. The account is only informational, so failing to read
+// the network doesn't fail the refresh of the connection.
+func ReadConnectionAccount(name string, d *schema.ResourceData, m interface{}) {
+
+	if d.Get("account_href").(string) != "" {
+		return
+	}
+
+	networkHref := d.Get("network_href").(string)
+	if networkHref == "" {
+		return
+	}
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	network, resp, err := config.Session.Client.NetworksApi.GetNetwork(ctx, filepath.Base(networkHref))
+	if err != nil {
+		if IsNotFound(resp) {
+			log.Printf("[Info] Network %s of %s %s not found, unable to set the account", networkHref, name, d.Id())
+			return
+		}
+		log.Printf("[WARN] Unable to read network %s for %s %s, the account isn't set: %s", networkHref, name, d.Id(), configuration.APIError(err))
+		return
+	}
+
+	if resp.StatusCode >= 300 {
+		log.Printf("[WARN] Error Response while reading network %s for %s %s, the account isn't set: code=%v", networkHref, name, d.Id(), resp.StatusCode)
+		return
+	}
+
+	if network.Account != nil {
+		d.Set("account_href", network.Account.Href)
+	}
+}
+
+// FlattenConnection sets the attributes shared by all connection types from the
+// connection returned by the Pureport API.
+func FlattenConnection(name string, d *schema.ResourceData, c interface{}) error {
+
+	conn := reflect.Indirect(reflect.ValueOf(c))
+	if conn.Kind() != reflect.Struct {
+		return fmt.Errorf("Error reading %s %s: unexpected connection type %T", name, d.Id(), c)
+	}
+
+	field := func(name string) interface{} {
+		return conn.FieldByName(name).Interface()
+	}
+
+	d.Set("description", field("Description"))
+	d.Set("high_availability", field("HighAvailability"))
+	d.Set("href", field("Href"))
+	d.Set("name", field("Name"))
+	// Resources accept speeds with units, so store the speed as a string for them
+	if _, ok := d.Get("speed").(string); ok {
+		d.Set("speed", strconv.Itoa(int(field("Speed").(int32))))
+	} else {
+		d.Set("speed", field("Speed"))
+	}
+
+	d.Set("billing_term", field("BillingTerm"))
+	d.Set("state", field("State"))
+	d.Set("health", ConnectionHealth(c))
+
+	// The error is set on the failed gateway when the connection itself has none
+	errorCode, errorMessage := GetConnectionError(c)
+	d.Set("error_code", errorCode)
+	d.Set("error_message", errorMessage)
+
+	d.Set("created_at", FormatTime(field("CreatedAt").(time.Time)))
+	d.Set("active_at", FormatTime(field("ActiveAt").(time.Time)))
+
+	if err := d.Set("customer_networks", FlattenCustomerNetworks(field("CustomerNetworks").([]client.CustomerNetwork))); err != nil {
+		return fmt.Errorf("Error setting customer networks for %s %s: %s", name, d.Id(), err)
+	}
+
+	// Add Gateway information. Both gateways are always set, with missing gateways
+	// left empty, so the secondary gateway is at the same index whether or not the
+	// connection is highly available.
+	var gateways []map[string]interface{}
+	for _, g := range []interface{}{field("PrimaryGateway"), field("SecondaryGateway")} {
+		switch g := g.(type) {
+		case *client.StandardGateway:
+			if g == nil {
+				g = &client.StandardGateway{}
+			}
+			gateways = append(gateways, FlattenStandardGateway(g))
+		case *client.VpnGateway:
+			if g == nil {
+				g = &client.VpnGateway{}
+			}
+			gateways = append(gateways, FlattenVpnGateway(g))
+		}
+	}
+
+	if err := d.Set("gateways", gateways); err != nil {
+		return fmt.Errorf("Error setting gateway information for %s %s: %s", name, d.Id(), err)
+	}
+
+	// NAT Configuration
+	if nat := field("Nat").(*client.NatConfig); nat != nil {
+		if err := d.Set("nat_config", FlattenNatConfig(nat)); err != nil {
+			return fmt.Errorf("Error setting NAT Configuration for %s %s: %s", name, d.Id(), err)
+		}
+	}
+
+	if location := field("Location").(*client.Link); location != nil {
+		if err := d.Set("location_href", location.Href); err != nil {
+			return fmt.Errorf("Error setting location for %s %s: %s", name, d.Id(), err)
+		}
+	}
+
+	if network := field("Network").(*client.Link); network != nil {
+		if err := d.Set("network_href", network.Href); err != nil {
+			return fmt.Errorf("Error setting network for %s %s: %s", name, d.Id(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+func TestFlattenConnection(t *testing.T) {
+
+	cases := []struct {
+		Name      string
+		Conn      interface{}
+		Gateway   map[string]*schema.Schema
+		Gateways  string
+		Primary   string
+		Secondary string
+		ErrorCode string
+		ErrorMsg  string
+	}{
+		{
+			Name: "standard",
+			Conn: client.AwsDirectConnectConnection{
+				Name:           "Test",
+				Speed:          50,
+				State:          "ACTIVE",
+				CreatedAt:      time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+				Location:       &client.Link{Href: "/locations/us-sea"},
+				Network:        &client.Link{Href: "/networks/network-abc"},
+				PrimaryGateway: &client.StandardGateway{Name: "Primary"},
+			},
+			Gateway:  StandardGatewaySchema,
+			Gateways: "2",
+			Primary:  "Primary",
+		},
+		{
+			Name: "vpn",
+			Conn: client.SiteIpSecVpnConnection{
+				Name:             "Test",
+				Speed:            50,
+				State:            "ACTIVE",
+				CreatedAt:        time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+				Location:         &client.Link{Href: "/locations/us-sea"},
+				Network:          &client.Link{Href: "/networks/network-abc"},
+				PrimaryGateway:   &client.VpnGateway{Name: "Primary", Auth: &client.PskAuthConfig{Type_: "PSK"}},
+				SecondaryGateway: &client.VpnGateway{Name: "Secondary", Auth: &client.PskAuthConfig{Type_: "PSK"}},
+			},
+			Gateway:   VpnGatewaySchema,
+			Gateways:  "2",
+			Primary:   "Primary",
+			Secondary: "Secondary",
+		},
+		{
+			Name: "provisioning",
+			Conn: client.SiteIpSecVpnConnection{
+				Name:      "Test",
+				Speed:     50,
+				State:     "PROVISIONING",
+				CreatedAt: time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+				Location:  &client.Link{Href: "/locations/us-sea"},
+				Network:   &client.Link{Href: "/networks/network-abc"},
+			},
+			Gateway:  VpnGatewaySchema,
+			Gateways: "2",
+		},
+		{
+			Name: "gateway error",
+			Conn: client.AwsDirectConnectConnection{
+				Name:           "Test",
+				Speed:          50,
+				State:          "FAILED_TO_PROVISION",
+				CreatedAt:      time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+				Location:       &client.Link{Href: "/locations/us-sea"},
+				Network:        &client.Link{Href: "/networks/network-abc"},
+				PrimaryGateway: &client.StandardGateway{Name: "Primary", ErrorCode: "VLAN_UNAVAILABLE", ErrorMessage: "No VLAN available"},
+			},
+			Gateway:   StandardGatewaySchema,
+			Gateways:  "2",
+			Primary:   "Primary",
+			ErrorCode: "VLAN_UNAVAILABLE",
+			ErrorMsg:  "No VLAN available",
+		},
+	}
+
+	for _, c := range cases {
+
+		s := GetBaseResourceConnectionSchema()
+		s["speed"] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Required: true,
+		}
+		s["gateways"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Resource{Schema: c.Gateway},
+		}
+
+		d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+		d.SetId("conn-123")
+
+		if err := FlattenConnection("Test Connection", d, c.Conn); err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		state := d.State()
+
+		expected := map[string]string{
+			"name":            "Test",
+			"speed":           "50",
+			"created_at":      "2019-07-01T12:00:00Z",
+			"location_href":   "/locations/us-sea",
+			"network_href":    "/networks/network-abc",
+			"gateways.#":      c.Gateways,
+			"gateways.0.name": c.Primary,
+			"gateways.1.name": c.Secondary,
+			"error_code":      c.ErrorCode,
+			"error_message":   c.ErrorMsg,
+		}
+
+		for k, v := range expected {
+			if state.Attributes[k] != v {
+				t.Errorf("%s: expected %s to be %q, got %q", c.Name, k, v, state.Attributes[k])
+			}
+		}
+	}
+}
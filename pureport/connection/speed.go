@@ -0,0 +1,184 @@
+package connection
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var speedPattern = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*([a-zA-Z]*)\s*$`)
+
+// speedUnits maps the supported speed units to their multiple of Mbps
+var speedUnits = map[string]float64{
+	"":     1,
+	"m":    1,
+	"mbps": 1,
+	"g":    1000,
+	"gbps": 1000,
+}
+
+// ParseSpeed parses a connection speed in Mbps. The speed is either a number of
+// Mbps or a number with a Mbps or Gbps unit, e.g. "500Mbps" or "1Gbps".
+func ParseSpeed(v string) (int, error) {
+
+	match := speedPattern.FindStringSubmatch(v)
+	if match == nil {
+		return 0, fmt.Errorf("invalid speed %q, expected a number of Mbps or a value such as 500Mbps or 1Gbps", v)
+	}
+
+	unit, ok := speedUnits[strings.ToLower(match[2])]
+	if !ok {
+		return 0, fmt.Errorf("invalid speed unit %q in %q, expected Mbps or Gbps", match[2], v)
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid speed %q: %s", v, err)
+	}
+
+	speed := value * unit
+	if speed != math.Trunc(speed) {
+		return 0, fmt.Errorf("invalid speed %q, the speed has to be a whole number of Mbps", v)
+	}
+
+	return int(speed), nil
+}
+
+// ValidSpeeds are the connection speeds in Mbps offered by Pureport. Speeds are
+// validated against them when the speeds supported at the location of the
+// connection can't be read from the API.
+var ValidSpeeds = []int{50, 100, 200, 300, 400, 500, 1000, 2000, 5000, 10000}
+
+// maxSpeed is the fastest speed in Mbps a connection can be configured with
+const maxSpeed = 100000
+
+// ValidateSpeedFormat is a SchemaValidateFunc for speeds parsed with ParseSpeed.
+// Which speeds are offered is checked against the API by ValidateSpeed, so new
+// speeds don't require a provider release.
+func ValidateSpeedFormat(i interface{}, k string) (s []string, es []error) {
+
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	speed, err := ParseSpeed(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+		return
+	}
+
+	if speed <= 0 || speed > maxSpeed {
+		es = append(es, fmt.Errorf("expected %s to be between 1 and %d Mbps, got %s", k, maxSpeed, v))
+	}
+
+	return
+}
+
+// checkValidSpeed returns an error when the speed isn't one of the ValidSpeeds
+func checkValidSpeed(speed int) error {
+
+	for _, valid := range ValidSpeeds {
+		if speed == valid {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Speed %d is not offered by Pureport. Valid speeds are %s Mbps", speed, formatSpeeds(ValidSpeeds))
+}
+
+// formatSpeeds formats a list of speeds, e.g. "50, 100, 200"
+func formatSpeeds(speeds []int) string {
+
+	s := make([]string, len(speeds))
+	for i, speed := range speeds {
+		s[i] = strconv.Itoa(speed)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// SuppressEquivalentSpeedDiffs suppresses diffs between speeds that are the same
+// number of Mbps, e.g. 1Gbps and 1000.
+func SuppressEquivalentSpeedDiffs(k, old, new string, d *schema.ResourceData) bool {
+
+	o, err := ParseSpeed(old)
+	if err != nil {
+		return false
+	}
+
+	n, err := ParseSpeed(new)
+	if err != nil {
+		return false
+	}
+
+	return o == n
+}
+
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type resourceGetter interface {
+	Get(string) interface{}
+}
+
+// GetSpeed returns the speed of the connection in Mbps, for both speeds
+// configured as a string with a unit and speeds stored as a number.
+func GetSpeed(d resourceGetter) int {
+
+	switch v := d.Get("speed").(type) {
+	case int:
+		return v
+	case string:
+		speed, _ := ParseSpeed(v)
+		return speed
+	}
+
+	return 0
+}
+
+// SpeedChangeInPlace returns whether the speed of a connection of the type can be
+// changed from old to new Mbps without replacing the connection.
+func SpeedChangeInPlace(connectionType string, old int, new int) bool {
+
+	switch connectionType {
+	case "AWS_DIRECT_CONNECT", "GOOGLE_CLOUD_INTERCONNECT":
+		// The bandwidth of hosted connections and partner attachments is fixed
+		// when they are provisioned by the cloud provider.
+		return false
+	case "AZURE_EXPRESS_ROUTE":
+		// ExpressRoute circuits can be upgraded in place, but not downgraded
+		return new >= old
+	}
+
+	return true
+}
+
+// ForceNewSpeed is a CustomizeDiff that replaces the connection when its speed
+// changes and the connection type can't change to the new speed in place, so the
+// plan shows the replacement.
+func ForceNewSpeed(connectionType string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+
+		if d.Id() == "" || !d.HasChange("speed") || !d.NewValueKnown("speed") {
+			return nil
+		}
+
+		o, n := d.GetChange("speed")
+
+		old, err := ParseSpeed(o.(string))
+		if err != nil {
+			return nil
+		}
+
+		new, err := ParseSpeed(n.(string))
+		if err != nil || old == new || SpeedChangeInPlace(connectionType, old, new) {
+			return nil
+		}
+
+		return d.ForceNew("speed")
+	}
+}
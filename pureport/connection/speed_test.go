@@ -0,0 +1,191 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/config/hcl2shim"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+func TestParseSpeed(t *testing.T) {
+
+	cases := []struct {
+		Value    string
+		Expected int
+		Error    bool
+	}{
+		{Value: "50", Expected: 50},
+		{Value: "500Mbps", Expected: 500},
+		{Value: "500 mbps", Expected: 500},
+		{Value: "1Gbps", Expected: 1000},
+		{Value: "1.5G", Expected: 1500},
+		{Value: " 10 Gbps ", Expected: 10000},
+		{Value: "1.5", Error: true},
+		{Value: "1Tbps", Error: true},
+		{Value: "fast", Error: true},
+		{Value: "", Error: true},
+	}
+
+	for _, c := range cases {
+
+		speed, err := ParseSpeed(c.Value)
+
+		if c.Error {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %d", c.Value, speed)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", c.Value, err)
+			continue
+		}
+
+		if speed != c.Expected {
+			t.Errorf("%q: expected %d, got %d", c.Value, c.Expected, speed)
+		}
+	}
+}
+
+func TestSuppressEquivalentSpeedDiffs(t *testing.T) {
+
+	cases := []struct {
+		Old      string
+		New      string
+		Expected bool
+	}{
+		{Old: "1000", New: "1Gbps", Expected: true},
+		{Old: "500", New: "500Mbps", Expected: true},
+		{Old: "500", New: "1Gbps", Expected: false},
+		{Old: "", New: "1Gbps", Expected: false},
+	}
+
+	for _, c := range cases {
+		if v := SuppressEquivalentSpeedDiffs("speed", c.Old, c.New, nil); v != c.Expected {
+			t.Errorf("%q to %q: expected %v, got %v", c.Old, c.New, c.Expected, v)
+		}
+	}
+}
+
+func TestSpeedChangeInPlace(t *testing.T) {
+
+	cases := []struct {
+		Type     string
+		Old      int
+		New      int
+		Expected bool
+	}{
+		{Type: "AWS_DIRECT_CONNECT", Old: 50, New: 100, Expected: false},
+		{Type: "GOOGLE_CLOUD_INTERCONNECT", Old: 100, New: 50, Expected: false},
+		{Type: "AZURE_EXPRESS_ROUTE", Old: 50, New: 1000, Expected: true},
+		{Type: "AZURE_EXPRESS_ROUTE", Old: 1000, New: 50, Expected: false},
+		{Type: "SITE_IPSEC_VPN", Old: 100, New: 50, Expected: true},
+		{Type: "DUMMY", Old: 50, New: 100, Expected: true},
+	}
+
+	for _, c := range cases {
+		if v := SpeedChangeInPlace(c.Type, c.Old, c.New); v != c.Expected {
+			t.Errorf("%s from %d to %d: expected %t, got %t", c.Type, c.Old, c.New, c.Expected, v)
+		}
+	}
+}
+
+func TestValidateSpeedFormat(t *testing.T) {
+
+	cases := []struct {
+		Value string
+		Error bool
+	}{
+		{Value: "50"},
+		{Value: "1Gbps"},
+		{Value: "10 Gbps"},
+		{Value: "150"},
+		{Value: "100Gbps"},
+		{Value: "200Gbps", Error: true},
+		{Value: "1.5Gbps"},
+		{Value: "1.5Mbps", Error: true},
+		{Value: "0", Error: true},
+		{Value: "fast", Error: true},
+	}
+
+	for _, c := range cases {
+
+		_, es := ValidateSpeedFormat(c.Value, "speed")
+
+		if c.Error && len(es) == 0 {
+			t.Errorf("%q: expected an error", c.Value)
+		}
+
+		if !c.Error && len(es) > 0 {
+			t.Errorf("%q: unexpected errors: %v", c.Value, es)
+		}
+	}
+}
+
+func TestCheckValidSpeed(t *testing.T) {
+
+	cases := []struct {
+		Speed int
+		Error bool
+	}{
+		{Speed: 50},
+		{Speed: 1000},
+		{Speed: 10000},
+		{Speed: 150, Error: true},
+		{Speed: 20000, Error: true},
+	}
+
+	for _, c := range cases {
+
+		err := checkValidSpeed(c.Speed)
+
+		if c.Error && err == nil {
+			t.Errorf("%d: expected an error", c.Speed)
+		}
+
+		if !c.Error && err != nil {
+			t.Errorf("%d: unexpected error: %s", c.Speed, err)
+		}
+	}
+}
+
+func TestValidateSpeed_unknownNetwork(t *testing.T) {
+
+	r := &schema.Resource{
+		Schema:        GetBaseResourceConnectionSchema(),
+		CustomizeDiff: ValidateSpeed("AWS_DIRECT_CONNECT"),
+	}
+
+	cases := []struct {
+		Speed string
+		Error bool
+	}{
+		{Speed: "100"},
+		{Speed: "1Gbps"},
+		{Speed: "123Mbps", Error: true},
+	}
+
+	for _, c := range cases {
+
+		// The network is created in the same apply, so its href isn't known yet
+		raw := map[string]interface{}{
+			"name":          "Test",
+			"speed":         c.Speed,
+			"location_href": "/locations/us-sea",
+			"network_href":  hcl2shim.UnknownVariableValue,
+		}
+
+		_, err := r.Diff(nil, &terraform.ResourceConfig{Raw: raw, Config: raw}, &configuration.Config{})
+
+		if c.Error && err == nil {
+			t.Errorf("%q: expected an error", c.Speed)
+		}
+
+		if !c.Error && err != nil {
+			t.Errorf("%q: unexpected error: %s", c.Speed, err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package connection
+
+import (
+	"fmt"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+// UpdateConnection replaces the connection of the resource with the body and waits
+// for it to become active again. The State is only updated once the connection is
+// active, so a failed update is planned again on the next apply.
+func UpdateConnection(name string, d *schema.ResourceData, m interface{}, body interface{}) error {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	d.Partial(true)
+
+	opts := client.UpdateConnectionOpts{
+		Body: optional.NewInterface(body),
+	}
+
+	_, resp, err := config.Session.Client.ConnectionsApi.UpdateConnection(ctx, d.Id(), &opts)
+	if err != nil {
+		return fmt.Errorf("Error while updating %s: err=%s", name, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while updating %s: code=%v", name, resp.StatusCode)
+	}
+
+	if err := WaitForConnection(name, d, m, schema.TimeoutUpdate); err != nil {
+		return fmt.Errorf("Error waiting for %s: err=%s", name, err)
+	}
+
+	d.Partial(false)
+
+	return nil
+}
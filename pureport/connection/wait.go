@@ -0,0 +1,117 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// DefaultTimeout is the default create and delete timeout of connections
+const DefaultTimeout = 6 * time.Minute
+
+// SetDefaultTimeouts replaces the default timeouts of the resource, e.g. with the
+// default_wait_timeout of the provider. The timeouts configured in the timeouts
+// block of the resource are decoded on top of the defaults when it is planned, so
+// they take precedence even when they are equal to the original default.
+func SetDefaultTimeouts(r *schema.Resource, timeout time.Duration) {
+
+	if r.Timeouts == nil {
+		return
+	}
+
+	timeouts := *r.Timeouts
+	for _, t := range []**time.Duration{&timeouts.Create, &timeouts.Read, &timeouts.Update, &timeouts.Delete, &timeouts.Default} {
+		if *t != nil {
+			*t = schema.DefaultTimeout(timeout)
+		}
+	}
+
+	r.Timeouts = &timeouts
+}
+
+// SimulatedFailureCode is the error code of a simulated provisioning failure. It
+// is retryable, so provisioning_retries is used for simulated failures as well.
+const SimulatedFailureCode = "SIMULATED_TRANSIENT_FAILURE"
+
+// Simulation delays and fails the provisioning of a connection while it's waited
+// for, so timeout and failure handling can be tested without a cloud.
+type Simulation struct {
+	Delay   time.Duration
+	Failure bool
+
+	start time.Time
+}
+
+// GetSimulation returns the simulated provisioning of the resource when it is
+// created, or nil when the resource doesn't simulate its provisioning.
+func GetSimulation(d *schema.ResourceData, key string) *Simulation {
+
+	if key != schema.TimeoutCreate {
+		return nil
+	}
+
+	sim := &Simulation{}
+
+	if delay, ok := d.GetOk("simulated_provisioning_delay"); ok {
+		sim.Delay = time.Duration(delay.(int)) * time.Second
+	}
+
+	if failure, ok := d.GetOk("simulated_failure"); ok {
+		sim.Failure = failure.(bool)
+	}
+
+	if sim.Delay == 0 && !sim.Failure {
+		return nil
+	}
+
+	return sim
+}
+
+// refresh returns the simulated state of the connection, or an empty state once
+// the simulation is over. The delay is waited for until the deadline of the wait,
+// after which the connection is reported as still provisioning.
+func (s *Simulation) refresh(ctx context.Context, name string, deadline time.Time) (string, error) {
+
+	if s.start.IsZero() {
+		s.start = time.Now()
+	}
+
+	end := s.start.Add(s.Delay)
+
+	if wait := time.Until(end); wait > 0 {
+		log.Printf("[Info] Simulating a provisioning delay of %s for %s", wait, name)
+
+		if untilDeadline := time.Until(deadline); untilDeadline < wait {
+			wait = untilDeadline
+		}
+
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("Stopped simulating the provisioning of %s: %s", name, ctx.Err())
+		case <-timer.C:
+		}
+
+		if time.Now().Before(end) {
+			return "PROVISIONING", nil
+		}
+	}
+
+	if s.Failure {
+		log.Printf("[Info] Simulating a provisioning failure for %s", name)
+
+		return "FAILED_TO_PROVISION", &ProvisioningError{
+			Name:    name,
+			State:   "FAILED_TO_PROVISION",
+			Code:    SimulatedFailureCode,
+			Message: "The failure was requested with simulated_failure",
+		}
+	}
+
+	return "", nil
+}
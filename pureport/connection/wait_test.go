@@ -0,0 +1,112 @@
+package connection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestSetDefaultTimeouts(t *testing.T) {
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultTimeout),
+			Delete: schema.DefaultTimeout(DefaultTimeout),
+		},
+	}
+
+	SetDefaultTimeouts(r, 30*time.Minute)
+
+	if r.Timeouts.Update != nil {
+		t.Errorf("expected no update timeout to be added, got %s", *r.Timeouts.Update)
+	}
+
+	// A timeout configured equal to the original default still takes precedence
+	config := &terraform.ResourceConfig{
+		Config: map[string]interface{}{
+			schema.TimeoutsConfigKey: []map[string]interface{}{
+				{"create": DefaultTimeout.String()},
+			},
+		},
+	}
+
+	timeouts := &schema.ResourceTimeout{}
+	if err := timeouts.ConfigDecode(r, config); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if *timeouts.Create != DefaultTimeout {
+		t.Errorf("expected the configured create timeout, got %s", *timeouts.Create)
+	}
+
+	if *timeouts.Delete != 30*time.Minute {
+		t.Errorf("expected the default wait timeout of the provider, got %s", *timeouts.Delete)
+	}
+}
+
+func TestSimulationRefresh(t *testing.T) {
+
+	sim := &Simulation{Delay: time.Hour}
+
+	state, err := sim.refresh(context.Background(), DummyConnectionName, time.Now().Add(10*time.Millisecond))
+	if err != nil || state != "PROVISIONING" {
+		t.Errorf("expected the connection to be provisioning until the delay is over, got state=%q err=%v", state, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := sim.refresh(ctx, DummyConnectionName, time.Now().Add(time.Hour)); err == nil {
+		t.Errorf("expected an error when the provider is stopped")
+	}
+
+	sim = &Simulation{Failure: true}
+
+	_, err = sim.refresh(context.Background(), DummyConnectionName, time.Now().Add(time.Hour))
+
+	perr, ok := err.(*ProvisioningError)
+	if !ok {
+		t.Fatalf("expected a provisioning error, got %v", err)
+	}
+
+	if !perr.Retryable() {
+		t.Errorf("expected the simulated failure to be retryable")
+	}
+
+	sim = &Simulation{}
+
+	if state, err := sim.refresh(context.Background(), DummyConnectionName, time.Now().Add(time.Hour)); err != nil || state != "" {
+		t.Errorf("expected the simulation to be over, got state=%q err=%v", state, err)
+	}
+}
+
+func TestGetSimulation(t *testing.T) {
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"simulated_provisioning_delay": {Type: schema.TypeInt, Optional: true},
+			"simulated_failure":            {Type: schema.TypeBool, Optional: true},
+		},
+	}
+
+	d := r.Data(nil)
+
+	if sim := GetSimulation(d, schema.TimeoutCreate); sim != nil {
+		t.Errorf("expected no simulation, got %+v", sim)
+	}
+
+	d.Set("simulated_provisioning_delay", 30)
+
+	sim := GetSimulation(d, schema.TimeoutCreate)
+	if sim == nil || sim.Delay != 30*time.Second || sim.Failure {
+		t.Errorf("expected a simulated delay of 30s, got %+v", sim)
+	}
+
+	if sim := GetSimulation(d, schema.TimeoutUpdate); sim != nil {
+		t.Errorf("expected no simulation for updates, got %+v", sim)
+	}
+}
@@ -0,0 +1,220 @@
+package pureport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/tags"
+)
+
+func dataSourceAccountHierarchy() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAccountHierarchyRead,
+
+		Schema: map[string]*schema.Schema{
+			"root_account_href": {
+				Type:        schema.TypeString,
+				Description: "The HREF of the account to start the hierarchy from. Defaults to the top level accounts available to the credentials.",
+				Optional:    true,
+			},
+			"child_accounts": {
+				Type:        schema.TypeMap,
+				Description: "Map of account id to HREF for every descendant of the root account(s).",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"accounts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"depth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"child_hrefs": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"tags": tags.TagsSchemaComputed(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccountHierarchyRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	rootHref := d.Get("root_account_href").(string)
+
+	ctx := config.Session.GetSessionContext()
+
+	accounts, resp, err := config.Session.Client.AccountsApi.FindAllAccounts(ctx, nil)
+	if err != nil {
+		d.SetId("")
+		return fmt.Errorf("Error when Reading Pureport Account data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		d.SetId("")
+		return fmt.Errorf("Error Response while Reading Pureport Account data: code=%v", resp.StatusCode)
+	}
+
+	byHref := make(map[string]client.Account)
+	for _, account := range accounts {
+		byHref[account.Href] = account
+	}
+
+	children := make(map[string][]client.Account)
+	var roots []client.Account
+
+	for _, account := range accounts {
+
+		// Accounts whose parent isn't visible to these credentials are treated as roots
+		if account.Parent != nil {
+			if _, ok := byHref[account.Parent.Href]; ok {
+				children[account.Parent.Href] = append(children[account.Parent.Href], account)
+				continue
+			}
+		}
+
+		roots = append(roots, account)
+	}
+
+	if rootHref != "" {
+		root, ok := byHref[rootHref]
+		if !ok {
+			return fmt.Errorf("Error Reading Pureport Account hierarchy: account %s not found", rootHref)
+		}
+		roots = []client.Account{root}
+	}
+
+	visited := make(map[string]bool)
+	out, descendants := flattenAccountHierarchy(roots, children, 0, visited)
+
+	// Accounts in a parent cycle, e.g. an account that is its own parent, aren't
+	// reached from the roots, so each of them that is left becomes a root too.
+	if rootHref == "" {
+
+		var unreached []client.Account
+		for _, account := range accounts {
+			if !visited[account.Id] {
+				unreached = append(unreached, account)
+			}
+		}
+
+		sort.Slice(unreached, func(i int, j int) bool {
+			return unreached[i].Name < unreached[j].Name
+		})
+
+		for _, account := range unreached {
+
+			subtree, subDescendants := flattenAccountHierarchy([]client.Account{account}, children, 0, visited)
+			out = append(out, subtree...)
+
+			for id, href := range subDescendants {
+				descendants[id] = href
+			}
+		}
+	}
+
+	if err := d.Set("accounts", out); err != nil {
+		return fmt.Errorf("Error reading account hierarchy: %s", err)
+	}
+
+	if err := d.Set("child_accounts", descendants); err != nil {
+		return fmt.Errorf("Error reading child accounts: %s", err)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("Error generating Id: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", hashcode.String(string(data))))
+
+	return nil
+}
+
+// flattenAccountHierarchy walks the account tree depth first, so each account
+// is followed by its children, and returns the descendants of the roots. Visited
+// accounts are skipped, so a parent cycle returned by the API ends the walk.
+func flattenAccountHierarchy(accounts []client.Account, children map[string][]client.Account, depth int, visited map[string]bool) (out []map[string]interface{}, descendants map[string]interface{}) {
+
+	descendants = make(map[string]interface{})
+
+	sort.Slice(accounts, func(i int, j int) bool {
+		return accounts[i].Name < accounts[j].Name
+	})
+
+	for _, account := range accounts {
+
+		if visited[account.Id] {
+			continue
+		}
+		visited[account.Id] = true
+
+		parentHref := ""
+		if account.Parent != nil {
+			parentHref = account.Parent.Href
+		}
+
+		var childHrefs []string
+		for _, child := range children[account.Href] {
+			childHrefs = append(childHrefs, child.Href)
+		}
+		sort.Strings(childHrefs)
+
+		out = append(out, map[string]interface{}{
+			"id":          account.Id,
+			"href":        account.Href,
+			"name":        account.Name,
+			"description": account.Description,
+			"parent_href": parentHref,
+			"depth":       depth,
+			"child_hrefs": childHrefs,
+			"tags":        account.Tags,
+		})
+
+		if depth > 0 {
+			descendants[account.Id] = account.Href
+		}
+
+		subtree, subDescendants := flattenAccountHierarchy(children[account.Href], children, depth+1, visited)
+		out = append(out, subtree...)
+
+		for id, href := range subDescendants {
+			descendants[id] = href
+		}
+	}
+
+	return
+}
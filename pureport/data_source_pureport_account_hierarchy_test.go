@@ -0,0 +1,132 @@
+package pureport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+const testAccDataSourceAccountHierarchyConfig_empty = `
+data "pureport_account_hierarchy" "empty" {
+}
+`
+
+func TestDataSourceAccountHierarchy_empty(t *testing.T) {
+
+	resourceName := "data.pureport_account_hierarchy.empty"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAccountHierarchyConfig_empty,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceAccountHierarchy(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "accounts.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "child_accounts.%", "1"),
+					testAccCheckDataSourceAccountMain(resourceName, "accounts.0"),
+					resource.TestCheckResourceAttr(resourceName, "accounts.0.depth", "0"),
+					resource.TestCheckResourceAttr(resourceName, "accounts.0.child_hrefs.#", "1"),
+					testAccCheckDataSourceAccountChildAccount(resourceName, "accounts.1"),
+					resource.TestCheckResourceAttr(resourceName, "accounts.1.depth", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "accounts.1.parent_href", resourceName, "accounts.0.href"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceAccountHierarchy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Account Hierarchy data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
+
+func TestFlattenAccountHierarchy_cycle(t *testing.T) {
+
+	a := client.Account{Id: "ac-a", Href: "/accounts/ac-a", Name: "A", Parent: &client.Link{Href: "/accounts/ac-b"}}
+	b := client.Account{Id: "ac-b", Href: "/accounts/ac-b", Name: "B", Parent: &client.Link{Href: "/accounts/ac-a"}}
+	c := client.Account{Id: "ac-c", Href: "/accounts/ac-c", Name: "C", Parent: &client.Link{Href: "/accounts/ac-c"}}
+
+	children := map[string][]client.Account{
+		a.Href: {b},
+		b.Href: {a},
+		c.Href: {c},
+	}
+
+	out, descendants := flattenAccountHierarchy([]client.Account{a, c}, children, 0, make(map[string]bool))
+
+	if len(out) != 3 {
+		t.Fatalf("expected each account once, got %d accounts", len(out))
+	}
+
+	for i, name := range []string{"A", "B", "C"} {
+		if out[i]["name"] != name {
+			t.Errorf("expected account %d to be %s, got %s", i, name, out[i]["name"])
+		}
+	}
+
+	if len(descendants) != 1 || descendants["ac-b"] != b.Href {
+		t.Errorf("expected only ac-b to be a descendant, got %v", descendants)
+	}
+}
+
+func TestDataSourceAccountHierarchyRead_cycle(t *testing.T) {
+
+	server := testAPIServer(map[string]string{
+		"/accounts": `[
+			{"id": "ac-root", "href": "/accounts/ac-root", "name": "Root"},
+			{"id": "ac-child", "href": "/accounts/ac-child", "name": "Child", "parent": {"href": "/accounts/ac-root"}},
+			{"id": "ac-a", "href": "/accounts/ac-a", "name": "A", "parent": {"href": "/accounts/ac-b"}},
+			{"id": "ac-b", "href": "/accounts/ac-b", "name": "B", "parent": {"href": "/accounts/ac-a"}},
+			{"id": "ac-self", "href": "/accounts/ac-self", "name": "Self", "parent": {"href": "/accounts/ac-self"}}
+		]`,
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceAccountHierarchy().Schema, map[string]interface{}{})
+
+	if err := dataSourceAccountHierarchyRead(d, testAPIConfig(t, server)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []struct {
+		Name  string
+		Depth int
+	}{
+		{Name: "Root", Depth: 0},
+		{Name: "Child", Depth: 1},
+		{Name: "A", Depth: 0},
+		{Name: "B", Depth: 1},
+		{Name: "Self", Depth: 0},
+	}
+
+	accounts := d.Get("accounts").([]interface{})
+	if len(accounts) != len(expected) {
+		t.Fatalf("expected %d accounts, got %d", len(expected), len(accounts))
+	}
+
+	for i, e := range expected {
+
+		account := accounts[i].(map[string]interface{})
+		if account["name"] != e.Name || account["depth"] != e.Depth {
+			t.Errorf("expected account %d to be %s at depth %d, got %s at depth %v", i, e.Name, e.Depth, account["name"], account["depth"])
+		}
+	}
+}
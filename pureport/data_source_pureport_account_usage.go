@@ -0,0 +1,254 @@
+package pureport
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+// billingPeriodFormat is the format of billing periods, e.g. 2019-07
+const billingPeriodFormat = "2006-01"
+
+func dataSourceAccountUsage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAccountUsageRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_href": {
+				Type:        schema.TypeString,
+				Description: "The account to report the usage for. Defaults to the account_href of the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"billing_period": {
+				Type:         schema.TypeString,
+				Description:  "The month to report the usage for, e.g. 2019-07. Defaults to the current month.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateBillingPeriod,
+			},
+			"include_child_accounts": {
+				Type:        schema.TypeBool,
+				Description: "Include the usage of the child accounts of the account.",
+				Optional:    true,
+				Default:     false,
+			},
+			"start_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"end_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"total_ingress": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"total_egress": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"networks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"account_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ingress": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"egress": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"account_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ingress": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"egress": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateBillingPeriod(i interface{}, k string) (s []string, es []error) {
+
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := time.Parse(billingPeriodFormat, v); err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a month in the format YYYY-MM, got %s", k, v))
+	}
+
+	return
+}
+
+// billingPeriod returns the start and end of the billing period, defaulting to
+// the month of the current time.
+func billingPeriod(period string, now time.Time) (start time.Time, end time.Time, err error) {
+
+	if period == "" {
+		now = now.UTC()
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	} else {
+		start, err = time.Parse(billingPeriodFormat, period)
+		if err != nil {
+			return
+		}
+	}
+
+	end = start.AddDate(0, 1, 0)
+
+	return
+}
+
+func dataSourceAccountUsageRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountHref, err := getAccountHref(d, m)
+	if err != nil {
+		return err
+	}
+	accountId := filepath.Base(accountHref)
+
+	start, end, err := billingPeriod(d.Get("billing_period").(string), time.Now())
+	if err != nil {
+		return fmt.Errorf("Invalid billing period: %s", err)
+	}
+
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.UsageByConnectionOpts{
+		Body: optional.NewInterface(client.UsageByConnectionOptions{
+			Date: &client.DateFilter{
+				Gte: start,
+				Lt:  end,
+			},
+			IncludeChildAccounts: d.Get("include_child_accounts").(bool),
+		}),
+	}
+
+	usage, resp, err := config.Session.Client.AccountMetricsApi.UsageByConnection(ctx, accountId, &opts)
+	if err != nil {
+		return fmt.Errorf("Error when Reading Account Usage data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while Reading Account Usage data: code=%v", resp.StatusCode)
+	}
+
+	connections, networks, ingress, egress := summarizeUsage(usage)
+
+	d.SetId(fmt.Sprintf("%s/%s", accountId, start.Format(billingPeriodFormat)))
+	d.Set("billing_period", start.Format(billingPeriodFormat))
+	d.Set("start_time", start.Format(time.RFC3339))
+	d.Set("end_time", end.Format(time.RFC3339))
+	d.Set("total_ingress", ingress)
+	d.Set("total_egress", egress)
+
+	if err := d.Set("connections", connections); err != nil {
+		return fmt.Errorf("Error setting connection usage for Account %s: %s", accountId, err)
+	}
+
+	if err := d.Set("networks", networks); err != nil {
+		return fmt.Errorf("Error setting network usage for Account %s: %s", accountId, err)
+	}
+
+	return nil
+}
+
+// summarizeUsage flattens the usage of each connection and totals it by network
+// and for the account. Both lists are sorted by href.
+func summarizeUsage(usage []client.NetworkConnectionEgressIngress) (connections []map[string]interface{}, networks []map[string]interface{}, ingress int, egress int) {
+
+	href := func(l *client.Link) string {
+		if l == nil {
+			return ""
+		}
+		return l.Href
+	}
+
+	byNetwork := make(map[string]map[string]interface{})
+
+	for _, u := range usage {
+
+		connections = append(connections, map[string]interface{}{
+			"connection_href": href(u.Connection),
+			"network_href":    href(u.Network),
+			"account_href":    href(u.Account),
+			"ingress":         int(u.Ingress),
+			"egress":          int(u.Egress),
+		})
+
+		n, ok := byNetwork[href(u.Network)]
+		if !ok {
+			n = map[string]interface{}{
+				"network_href": href(u.Network),
+				"account_href": href(u.Account),
+				"ingress":      0,
+				"egress":       0,
+			}
+			byNetwork[href(u.Network)] = n
+			networks = append(networks, n)
+		}
+
+		n["ingress"] = n["ingress"].(int) + int(u.Ingress)
+		n["egress"] = n["egress"].(int) + int(u.Egress)
+
+		ingress += int(u.Ingress)
+		egress += int(u.Egress)
+	}
+
+	sort.Slice(connections, func(i int, j int) bool {
+		return connections[i]["connection_href"].(string) < connections[j]["connection_href"].(string)
+	})
+
+	sort.Slice(networks, func(i int, j int) bool {
+		return networks[i]["network_href"].(string) < networks[j]["network_href"].(string)
+	})
+
+	return
+}
@@ -0,0 +1,105 @@
+package pureport
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+const testAccDataSourceAccountUsageConfig_basic = `
+data "pureport_accounts" "main" {
+  filter {
+    name = "Name"
+    values = ["Terraform .*"]
+  }
+}
+
+data "pureport_account_usage" "basic" {
+  account_href   = "${data.pureport_accounts.main.accounts.0.href}"
+  billing_period = "2019-07"
+}
+`
+
+func TestDataSourceAccountUsage_basic(t *testing.T) {
+
+	resourceName := "data.pureport_account_usage.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAccountUsageConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile("ac-.{16}/2019-07")),
+					resource.TestCheckResourceAttr(resourceName, "start_time", "2019-07-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(resourceName, "end_time", "2019-08-01T00:00:00Z"),
+					resource.TestCheckResourceAttrSet(resourceName, "total_ingress"),
+					resource.TestCheckResourceAttrSet(resourceName, "total_egress"),
+				),
+			},
+		},
+	})
+}
+
+func TestBillingPeriod(t *testing.T) {
+
+	now := time.Date(2019, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		Period string
+		Start  string
+		End    string
+	}{
+		{Period: "", Start: "2019-12-01T00:00:00Z", End: "2020-01-01T00:00:00Z"},
+		{Period: "2019-02", Start: "2019-02-01T00:00:00Z", End: "2019-03-01T00:00:00Z"},
+	}
+
+	for _, c := range cases {
+
+		start, end, err := billingPeriod(c.Period, now)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", c.Period, err)
+		}
+
+		if start.Format(time.RFC3339) != c.Start || end.Format(time.RFC3339) != c.End {
+			t.Errorf("%q: expected %s to %s, got %s to %s", c.Period, c.Start, c.End, start.Format(time.RFC3339), end.Format(time.RFC3339))
+		}
+	}
+
+	if _, _, err := billingPeriod("July", now); err == nil {
+		t.Errorf("expected an error for an invalid billing period")
+	}
+}
+
+func TestSummarizeUsage(t *testing.T) {
+
+	usage := []client.NetworkConnectionEgressIngress{
+		{Network: &client.Link{Href: "/networks/network-b"}, Connection: &client.Link{Href: "/connections/conn-c"}, Ingress: 5, Egress: 1},
+		{Network: &client.Link{Href: "/networks/network-a"}, Connection: &client.Link{Href: "/connections/conn-b"}, Ingress: 10, Egress: 20},
+		{Network: &client.Link{Href: "/networks/network-a"}, Connection: &client.Link{Href: "/connections/conn-a"}, Ingress: 1, Egress: 2},
+	}
+
+	connections, networks, ingress, egress := summarizeUsage(usage)
+
+	if ingress != 16 || egress != 23 {
+		t.Errorf("expected totals of 16 and 23, got %d and %d", ingress, egress)
+	}
+
+	if len(connections) != 3 || connections[0]["connection_href"] != "/connections/conn-a" {
+		t.Errorf("expected connections sorted by href, got %v", connections)
+	}
+
+	expected := []map[string]interface{}{
+		{"network_href": "/networks/network-a", "account_href": "", "ingress": 11, "egress": 22},
+		{"network_href": "/networks/network-b", "account_href": "", "ingress": 5, "egress": 1},
+	}
+
+	if !reflect.DeepEqual(networks, expected) {
+		t.Errorf("expected %v, got %v", expected, networks)
+	}
+}
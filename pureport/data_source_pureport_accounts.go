@@ -60,7 +60,7 @@ func dataSourceAccountsRead(d *schema.ResourceData, m interface{}) error {
 	accounts, resp, err := config.Session.Client.AccountsApi.FindAllAccounts(ctx, nil)
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error when Reading Pureport Account data: %v", err)
+		return fmt.Errorf("Error when Reading Pureport Account data: %v", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -65,6 +65,7 @@ func TestDataSourceAzureConnection_basic(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceName, "state", "ACTIVE"),
 						resource.TestCheckResourceAttr(resourceName, "location_href", "/locations/us-sea"),
 						resource.TestMatchResourceAttr(resourceName, "network_href", regexp.MustCompile("/networks/network-.{16}")),
+						resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
 						resource.TestCheckResourceAttr(resourceName, "cloud_service_hrefs.#", "0"),
 
 						resource.TestCheckResourceAttr(resourceName, "tags.#", "0"),
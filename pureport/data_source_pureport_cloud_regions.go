@@ -4,20 +4,100 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/pureport/pureport-sdk-go/pureport/client"
 	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
 	"github.com/pureport/terraform-provider-pureport/pureport/filter"
 )
 
+// geographicRegions maps fragments of the provider assigned region identifiers to
+// their geographic region. They are matched in order, e.g. so "australiaeast" isn't
+// matched as a US region.
+var geographicRegions = []struct {
+	fragment string
+	region   string
+}{
+	{"northamerica", "NORTH_AMERICA"},
+	{"southamerica", "SOUTH_AMERICA"},
+	{"southafrica", "AFRICA"},
+	{"australia", "ASIA_PACIFIC"},
+	{"asia", "ASIA_PACIFIC"},
+	{"japan", "ASIA_PACIFIC"},
+	{"korea", "ASIA_PACIFIC"},
+	{"india", "ASIA_PACIFIC"},
+	{"europe", "EUROPE"},
+	{"france", "EUROPE"},
+	{"germany", "EUROPE"},
+	{"norway", "EUROPE"},
+	{"switzerland", "EUROPE"},
+	{"uk", "EUROPE"},
+	{"brazil", "SOUTH_AMERICA"},
+	{"canada", "NORTH_AMERICA"},
+	{"uae", "MIDDLE_EAST"},
+	{"us", "NORTH_AMERICA"},
+}
+
+// geographicRegionPrefixes maps the prefixes of dash separated region identifiers,
+// e.g. "eu-west-1", to their geographic region
+var geographicRegionPrefixes = map[string]string{
+	"us": "NORTH_AMERICA",
+	"ca": "NORTH_AMERICA",
+	"sa": "SOUTH_AMERICA",
+	"eu": "EUROPE",
+	"ap": "ASIA_PACIFIC",
+	"me": "MIDDLE_EAST",
+	"af": "AFRICA",
+}
+
+// geographicRegion returns the geographic region of the cloud region derived from
+// its provider assigned identifier, or an empty string when it isn't known.
+func geographicRegion(r client.CloudRegion) string {
+
+	id := strings.ToLower(r.ProviderAssignedId)
+
+	if i := strings.Index(id, "-"); i > 0 {
+		if region, ok := geographicRegionPrefixes[id[:i]]; ok {
+			return region
+		}
+	}
+
+	for _, g := range geographicRegions {
+		if strings.Contains(id, g.fragment) {
+			return g.region
+		}
+	}
+
+	return ""
+}
+
 func dataSourceCloudRegions() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceCloudRegionsRead,
 
 		Schema: map[string]*schema.Schema{
 			"filter": filter.DataSourceFiltersSchema(),
+			"cloud_provider": {
+				Type:        schema.TypeString,
+				Description: "Only include the regions of the cloud provider, e.g. AWS.",
+				Optional:    true,
+			},
+			"geographic_region": {
+				Type:        schema.TypeString,
+				Description: "Only include the regions in the geographic region, e.g. NORTH_AMERICA.",
+				Optional:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"NORTH_AMERICA",
+					"SOUTH_AMERICA",
+					"EUROPE",
+					"ASIA_PACIFIC",
+					"MIDDLE_EAST",
+					"AFRICA",
+				}, true),
+			},
 			"regions": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -39,6 +119,10 @@ func dataSourceCloudRegions() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"geographic_region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -56,7 +140,7 @@ func dataSourceCloudRegionsRead(d *schema.ResourceData, m interface{}) error {
 	regions, resp, err := config.Session.Client.CloudRegionsApi.GetCloudRegions(ctx)
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error when Reading Cloud Region data: %v", err)
+		return fmt.Errorf("Error when Reading Cloud Region data: %v", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -69,12 +153,30 @@ func dataSourceCloudRegionsRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error Response while Reading Cloud Region data")
 	}
 
+	// Filter by provider and geographic region
+	provider := d.Get("cloud_provider").(string)
+	geographic := d.Get("geographic_region").(string)
+
+	var matchingRegions []client.CloudRegion
+	for _, r := range regions {
+
+		if provider != "" && !strings.EqualFold(r.Provider, provider) {
+			continue
+		}
+
+		if geographic != "" && !strings.EqualFold(geographicRegion(r), geographic) {
+			continue
+		}
+
+		matchingRegions = append(matchingRegions, r)
+	}
+
 	// Filter the results
 	var filteredRegions []client.CloudRegion
 	if filtersOk {
 
-		input := make([]interface{}, len(regions))
-		for i, x := range regions {
+		input := make([]interface{}, len(matchingRegions))
+		for i, x := range matchingRegions {
 			input[i] = x
 		}
 
@@ -84,7 +186,7 @@ func dataSourceCloudRegionsRead(d *schema.ResourceData, m interface{}) error {
 		}
 
 	} else {
-		filteredRegions = regions
+		filteredRegions = matchingRegions
 	}
 
 	// Sort the list
@@ -112,10 +214,11 @@ func flattenRegions(regions []client.CloudRegion) (out []map[string]interface{})
 	for _, cr := range regions {
 
 		r := map[string]interface{}{
-			"id":         cr.Id,
-			"name":       cr.DisplayName,
-			"provider":   cr.Provider,
-			"identifier": cr.ProviderAssignedId,
+			"id":                cr.Id,
+			"name":              cr.DisplayName,
+			"provider":          cr.Provider,
+			"identifier":        cr.ProviderAssignedId,
+			"geographic_region": geographicRegion(cr),
 		}
 
 		out = append(out, r)
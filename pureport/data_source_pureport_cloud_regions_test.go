@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
 )
 
 const testAccDataSourceCloudRegionsConfig_empty = `
@@ -22,6 +23,13 @@ data "pureport_cloud_regions" "name_filter" {
 }
 `
 
+const testAccDataSourceCloudRegionsConfig_provider_filter = `
+data "pureport_cloud_regions" "provider_filter" {
+  cloud_provider    = "aws"
+  geographic_region = "EUROPE"
+}
+`
+
 func TestDataSourceCloudRegionsDataSource_empty(t *testing.T) {
 
 	resourceName := "data.pureport_cloud_regions.empty"
@@ -41,6 +49,7 @@ func TestDataSourceCloudRegionsDataSource_empty(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "regions.0.name", "Asia Pacific (Tokyo)"),
 					resource.TestCheckResourceAttr(resourceName, "regions.0.provider", "AWS"),
 					resource.TestCheckResourceAttr(resourceName, "regions.0.identifier", "ap-northeast-1"),
+					resource.TestCheckResourceAttr(resourceName, "regions.0.geographic_region", "ASIA_PACIFIC"),
 				),
 			},
 		},
@@ -77,6 +86,54 @@ func TestDataSourceCloudRegionsDataSource_name_filter(t *testing.T) {
 	})
 }
 
+func TestDataSourceCloudRegionsDataSource_provider_filter(t *testing.T) {
+
+	resourceName := "data.pureport_cloud_regions.provider_filter"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCloudRegionsConfig_provider_filter,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceCloudRegions(resourceName),
+
+					resource.TestCheckResourceAttr(resourceName, "regions.0.id", "aws-eu-central-1"),
+					resource.TestCheckResourceAttr(resourceName, "regions.0.provider", "AWS"),
+					resource.TestCheckResourceAttr(resourceName, "regions.0.geographic_region", "EUROPE"),
+				),
+			},
+		},
+	})
+}
+
+func TestGeographicRegion(t *testing.T) {
+
+	cases := []struct {
+		Identifier string
+		Expected   string
+	}{
+		{Identifier: "us-east-1", Expected: "NORTH_AMERICA"},
+		{Identifier: "eu-west-2", Expected: "EUROPE"},
+		{Identifier: "ap-southeast-2", Expected: "ASIA_PACIFIC"},
+		{Identifier: "northamerica-northeast1", Expected: "NORTH_AMERICA"},
+		{Identifier: "europe-west1", Expected: "EUROPE"},
+		{Identifier: "asia-east1", Expected: "ASIA_PACIFIC"},
+		{Identifier: "eastus2", Expected: "NORTH_AMERICA"},
+		{Identifier: "westeurope", Expected: "EUROPE"},
+		{Identifier: "australiaeast", Expected: "ASIA_PACIFIC"},
+		{Identifier: "brazilsouth", Expected: "SOUTH_AMERICA"},
+		{Identifier: "unknown", Expected: ""},
+	}
+
+	for _, c := range cases {
+		if r := geographicRegion(client.CloudRegion{ProviderAssignedId: c.Identifier}); r != c.Expected {
+			t.Errorf("%s: expected %q, got %q", c.Identifier, c.Expected, r)
+		}
+	}
+}
+
 func testAccCheckDataSourceCloudRegions(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
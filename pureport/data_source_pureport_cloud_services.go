@@ -72,7 +72,7 @@ func dataSourceCloudServicesRead(d *schema.ResourceData, m interface{}) error {
 	services, resp, err := config.Session.Client.CloudServicesApi.GetCloudServices(ctx)
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error when Reading Cloud Services data: %v", err)
+		return fmt.Errorf("Error when Reading Cloud Services data: %v", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
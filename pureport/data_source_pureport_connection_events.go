@@ -0,0 +1,131 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+)
+
+func dataSourceConnectionEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConnectionEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("conn-.{16}"), "Connection ID must start with 'conn-' with 16 trailing characters."),
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of events to return, most recent first.",
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"result": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"completed_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConnectionEventsRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	connectionId := d.Get("connection_id").(string)
+	limit := d.Get("limit").(int)
+	ctx := config.Session.GetSessionContext()
+
+	tasks, resp, err := config.Session.Client.ConnectionsApi.GetConnectionTasks(ctx, connectionId)
+	if err != nil {
+		return fmt.Errorf("Error reading events for Connection %s: %s", connectionId, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while reading events for Connection %s: code=%v", connectionId, resp.StatusCode)
+	}
+
+	// Most recent first
+	sort.Slice(tasks, func(i int, j int) bool {
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+
+	if err := d.Set("events", flattenConnectionEvents(tasks)); err != nil {
+		return fmt.Errorf("Error setting events for Connection %s: %s", connectionId, err)
+	}
+
+	d.SetId(connectionId)
+
+	return nil
+}
+
+func flattenConnectionEvents(tasks []client.Task) (out []map[string]interface{}) {
+
+	for _, task := range tasks {
+		out = append(out, map[string]interface{}{
+			"id":           task.Id,
+			"href":         task.Href,
+			"type":         task.Type_,
+			"description":  task.Description,
+			"state":        task.State,
+			"result":       task.Result,
+			"created_at":   connection.FormatTime(task.CreatedAt),
+			"updated_at":   connection.FormatTime(task.UpdatedAt),
+			"completed_at": connection.FormatTime(task.CompletedAt),
+		})
+	}
+
+	return
+}
@@ -0,0 +1,56 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccDataSourceConnectionEventsConfig_basic = testAccDataSourceAwsConnectionConfig_common + `
+data "pureport_connection_events" "basic" {
+  connection_id = "${data.pureport_connections.main.connections.0.id}"
+  limit = 5
+}
+`
+
+func TestDataSourceConnectionEvents_basic(t *testing.T) {
+
+	resourceName := "data.pureport_connection_events.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceConnectionEventsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceConnectionEvents(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile("conn-.{16}")),
+					resource.TestCheckResourceAttrSet(resourceName, "events.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "events.0.type"),
+					resource.TestCheckResourceAttrSet(resourceName, "events.0.created_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceConnectionEvents(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Connection Events data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
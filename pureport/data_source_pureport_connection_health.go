@@ -0,0 +1,98 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+)
+
+func dataSourceConnectionHealth() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConnectionHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("conn-.{16}"), "Connection ID must start with 'conn-' with 16 trailing characters."),
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"health": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"gateways": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"link_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bgp_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"up": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConnectionHealthRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	connectionId := d.Get("connection_id").(string)
+	ctx := config.Session.GetSessionContext()
+
+	c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
+	if err != nil {
+		return fmt.Errorf("Error reading health for Connection %s: %s", connectionId, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while reading health for Connection %s: code=%v", connectionId, resp.StatusCode)
+	}
+
+	var gateways []map[string]interface{}
+	for _, g := range connection.GetGatewayStatuses(c) {
+		gateways = append(gateways, map[string]interface{}{
+			"name":       g.Name,
+			"state":      g.State,
+			"link_state": g.LinkState,
+			"bgp_state":  g.BgpState,
+			"up":         g.Up(),
+		})
+	}
+
+	d.SetId(connectionId)
+	d.Set("state", connection.ConnectionState(c))
+	d.Set("health", connection.ConnectionHealth(c))
+
+	if err := d.Set("gateways", gateways); err != nil {
+		return fmt.Errorf("Error setting gateway health for Connection %s: %s", connectionId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccDataSourceConnectionHealthConfig_basic = testAccDataSourceAwsConnectionConfig_common + `
+data "pureport_connection_health" "basic" {
+  connection_id = "${data.pureport_connections.main.connections.0.id}"
+}
+`
+
+func TestDataSourceConnectionHealth_basic(t *testing.T) {
+
+	resourceName := "data.pureport_connection_health.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceConnectionHealthConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceConnectionHealth(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile("conn-.{16}")),
+					resource.TestCheckResourceAttr(resourceName, "state", "ACTIVE"),
+					resource.TestMatchResourceAttr(resourceName, "health", regexp.MustCompile("HEALTHY|DEGRADED|DOWN")),
+					resource.TestCheckResourceAttr(resourceName, "gateways.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceConnectionHealth(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Connection Health data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
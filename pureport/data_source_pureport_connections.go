@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -20,6 +22,7 @@ func dataSourceConnections() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"filter": filter.DataSourceFiltersSchema(),
+			"tags":   tags.TagsFilterSchema(),
 			"network_href": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -62,6 +65,16 @@ func dataSourceConnections() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Description: "The Terraform resource type used to manage the connection.",
+							Computed:    true,
+						},
+						"import_command": {
+							Type:        schema.TypeString,
+							Description: "The command to import the connection into the Terraform State.",
+							Computed:    true,
+						},
 
 						"tags": tags.TagsSchemaComputed(),
 					},
@@ -82,7 +95,7 @@ func dataSourceConnectionsRead(d *schema.ResourceData, m interface{}) error {
 	connections, resp, err := config.Session.Client.ConnectionsApi.GetConnections(ctx, networkId)
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error when Reading Connections data: %v", err)
+		return fmt.Errorf("Error when Reading Connections data: %v", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -90,6 +103,19 @@ func dataSourceConnectionsRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error Response while Reading Connections data")
 	}
 
+	// Only include the connections with the tags
+	if required, ok := d.GetOk("tags"); ok {
+
+		var tagged []client.Connection
+		for _, x := range connections {
+			if tags.MatchTags(required.(map[string]interface{}), x.Tags) {
+				tagged = append(tagged, x)
+			}
+		}
+
+		connections = tagged
+	}
+
 	// Filter the results
 	var filteredConnections []client.Connection
 
@@ -129,20 +155,50 @@ func dataSourceConnectionsRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// connectionResourceTypes maps the API connection types to the resource types
+var connectionResourceTypes = map[string]string{
+	"AWS_DIRECT_CONNECT":        "pureport_aws_connection",
+	"AZURE_EXPRESS_ROUTE":       "pureport_azure_connection",
+	"DUMMY":                     "pureport_dummy_connection",
+	"GOOGLE_CLOUD_INTERCONNECT": "pureport_google_cloud_connection",
+	"SITE_IPSEC_VPN":            "pureport_site_vpn_connection",
+}
+
+var invalidResourceNameChars = regexp.MustCompile("[^a-z0-9_-]+")
+
+// importCommand returns the terraform import command for the connection, naming the
+// resource after the connection. Unsupported connection types return an empty string.
+func importCommand(c client.Connection) string {
+
+	resourceType, ok := connectionResourceTypes[c.Type_]
+	if !ok {
+		return ""
+	}
+
+	name := strings.Trim(invalidResourceNameChars.ReplaceAllString(strings.ToLower(c.Name), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "connection_" + name
+	}
+
+	return fmt.Sprintf("terraform import %s.%s %s", resourceType, name, c.Id)
+}
+
 func flattenConnections(connections []client.Connection) (out []map[string]interface{}) {
 
 	for _, c := range connections {
 
 		out = append(out, map[string]interface{}{
-			"id":            c.Id,
-			"href":          c.Href,
-			"name":          c.Name,
-			"description":   c.Description,
-			"type":          c.Type_,
-			"speed":         c.Speed,
-			"location_href": c.Location.Href,
-			"state":         c.State,
-			"tags":          c.Tags,
+			"id":             c.Id,
+			"href":           c.Href,
+			"name":           c.Name,
+			"description":    c.Description,
+			"type":           c.Type_,
+			"speed":          c.Speed,
+			"location_href":  c.Location.Href,
+			"state":          c.State,
+			"resource_type":  connectionResourceTypes[c.Type_],
+			"import_command": importCommand(c),
+			"tags":           c.Tags,
 		})
 	}
 
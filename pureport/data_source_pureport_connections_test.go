@@ -113,6 +113,8 @@ func testAccCheckDataSourceConnectionsTest1(resourceName, connection string) res
 			resource.TestCheckResourceAttr(resourceName, connection+".speed", "50"),
 			resource.TestCheckResourceAttr(resourceName, connection+".location_href", "/locations/us-wdc"),
 			resource.TestCheckResourceAttr(resourceName, connection+".state", "ACTIVE"),
+			resource.TestCheckResourceAttr(resourceName, connection+".resource_type", "pureport_aws_connection"),
+			resource.TestMatchResourceAttr(resourceName, connection+".import_command", regexp.MustCompile("terraform import pureport_aws_connection.connectionstest-1 conn-.{16}")),
 
 			resource.TestCheckResourceAttr(resourceName, connection+".tags.#", "0"),
 		)
@@ -127,6 +129,8 @@ func testAccCheckDataSourceConnectionsTest1(resourceName, connection string) res
 		resource.TestCheckResourceAttr(resourceName, connection+".speed", "50"),
 		resource.TestCheckResourceAttr(resourceName, connection+".location_href", "/locations/us-sea"),
 		resource.TestCheckResourceAttr(resourceName, connection+".state", "ACTIVE"),
+		resource.TestCheckResourceAttr(resourceName, connection+".resource_type", "pureport_aws_connection"),
+		resource.TestMatchResourceAttr(resourceName, connection+".import_command", regexp.MustCompile("terraform import pureport_aws_connection.connectionstest-1 conn-.{16}")),
 
 		resource.TestCheckResourceAttr(resourceName, connection+".tags.#", "0"),
 	)
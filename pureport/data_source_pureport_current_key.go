@@ -0,0 +1,109 @@
+package pureport
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+func dataSourceCurrentKey() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCurrentKeyRead,
+
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role_hrefs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceCurrentKeyRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+
+	credentials, err := config.Session.Credentials.Get()
+	if err != nil {
+		return fmt.Errorf("Error reading the current API Key: %s", err)
+	}
+
+	if credentials.APIKey == "" {
+		return fmt.Errorf("Error reading the current API Key: the provider isn't authenticated with an API Key")
+	}
+
+	ctx := config.Session.GetSessionContext()
+
+	// The API Key doesn't identify its account, so search the accounts the
+	// key has access to for the one it belongs to.
+	accounts, resp, err := config.Session.Client.AccountsApi.FindAllAccounts(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Error when Reading Pureport Account data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while Reading Pureport Account data: code=%v", resp.StatusCode)
+	}
+
+	var key *client.ApiKey
+	for _, account := range accounts {
+
+		found, resp, err := config.Session.Client.ApikeysApi.GetApiKey(ctx, credentials.APIKey, account.Id)
+		if err != nil || resp.StatusCode >= 300 {
+			log.Printf("[Info] API Key %s not found in account %s", credentials.APIKey, account.Id)
+			continue
+		}
+
+		key = &found
+		break
+	}
+
+	if key == nil {
+		return fmt.Errorf("Unable to find the current API Key %s in any account", credentials.APIKey)
+	}
+
+	d.SetId(key.Key)
+	d.Set("key", key.Key)
+	d.Set("href", key.Href)
+	d.Set("name", key.Name)
+	d.Set("description", key.Description)
+
+	if key.Account != nil {
+		d.Set("account_href", key.Account.Href)
+	}
+
+	var roleHrefs []string
+	for _, r := range key.Roles {
+		roleHrefs = append(roleHrefs, r.Href)
+	}
+
+	if err := d.Set("role_hrefs", roleHrefs); err != nil {
+		return fmt.Errorf("Error setting roles for the current API Key: %s", err)
+	}
+
+	return nil
+}
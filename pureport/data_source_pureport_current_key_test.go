@@ -0,0 +1,54 @@
+package pureport
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccDataSourceCurrentKeyConfig_basic = `
+data "pureport_current_key" "basic" {
+}
+`
+
+func TestDataSourceCurrentKey_basic(t *testing.T) {
+
+	resourceName := "data.pureport_current_key.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCurrentKeyConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceCurrentKey(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "key", os.Getenv("PUREPORT_API_KEY")),
+					resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
+					resource.TestCheckResourceAttrSet(resourceName, "name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceCurrentKey(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Current Key data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
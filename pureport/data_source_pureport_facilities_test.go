@@ -0,0 +1,55 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccDataSourceFacilitiesConfig_empty = `
+data "pureport_facilities" "empty" {
+}
+`
+
+func TestDataSourceFacilities_empty(t *testing.T) {
+
+	resourceName := "data.pureport_facilities.empty"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFacilitiesConfig_empty,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceFacilities(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "facilities.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "facilities.0.id"),
+					resource.TestMatchResourceAttr(resourceName, "facilities.0.href", regexp.MustCompile("/facilities/.+")),
+					resource.TestCheckResourceAttrSet(resourceName, "facilities.0.name"),
+					resource.TestCheckResourceAttr(resourceName, "facilities.0.address.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceFacilities(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Facilities data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,216 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+)
+
+func dataSourceGatewayBGPRoutes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGatewayBGPRoutesRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("conn-.{16}"), "Connection ID must start with 'conn-' with 16 trailing characters."),
+			},
+			"gateways": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"learned_prefixes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"advertised_prefixes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"routes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"prefix": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"next_hop": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"as_path": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeInt},
+									},
+									"origin": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"local_preference": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"metric": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"weight": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"best": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"valid": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"internal": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGatewayBGPRoutesRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	connectionId := d.Get("connection_id").(string)
+	ctx := config.Session.GetSessionContext()
+
+	c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
+	if err != nil {
+		return fmt.Errorf("Error reading Connection %s: %s", connectionId, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while reading Connection %s: code=%v", connectionId, resp.StatusCode)
+	}
+
+	var gateways []map[string]interface{}
+	for _, g := range connection.GetGatewayStatuses(c) {
+
+		if g.Id == "" {
+			continue
+		}
+
+		routes, resp, err := config.Session.Client.GatewaysApi.GetGatewayBGPRoutes(ctx, g.Id)
+		if err != nil {
+			return fmt.Errorf("Error reading BGP routes for Gateway %s of Connection %s: %s", g.Id, connectionId, configuration.APIError(err))
+		}
+
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("Error Response while reading BGP routes for Gateway %s of Connection %s: code=%v", g.Id, connectionId, resp.StatusCode)
+		}
+
+		learned, advertised := bgpRoutePrefixes(routes)
+
+		gateways = append(gateways, map[string]interface{}{
+			"id":                  g.Id,
+			"href":                connection.GatewayHref(g.Id),
+			"name":                g.Name,
+			"learned_prefixes":    learned,
+			"advertised_prefixes": advertised,
+			"routes":              flattenBGPRoutes(routes),
+		})
+	}
+
+	d.SetId(connectionId)
+
+	if err := d.Set("gateways", gateways); err != nil {
+		return fmt.Errorf("Error setting BGP routes for Connection %s: %s", connectionId, err)
+	}
+
+	return nil
+}
+
+// bgpRoutePrefixes returns the sorted prefixes of the valid routes of a gateway.
+// Routes learned from the BGP peer of the gateway are external, while the routes
+// the gateway advertises to its peer are learned from the rest of the network.
+func bgpRoutePrefixes(routes []client.BgpRoute) (learned []string, advertised []string) {
+
+	seen := make(map[string]bool)
+
+	for _, r := range routes {
+
+		if !r.Valid || r.Network == "" {
+			continue
+		}
+
+		key := fmt.Sprintf("%t/%s", r.Internal, r.Network)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if r.Internal {
+			advertised = append(advertised, r.Network)
+		} else {
+			learned = append(learned, r.Network)
+		}
+	}
+
+	sort.Strings(learned)
+	sort.Strings(advertised)
+
+	return
+}
+
+func flattenBGPRoutes(routes []client.BgpRoute) (out []map[string]interface{}) {
+
+	for _, r := range routes {
+
+		path := make([]int, len(r.Path))
+		for i, as := range r.Path {
+			path[i] = int(as)
+		}
+
+		out = append(out, map[string]interface{}{
+			"prefix":           r.Network,
+			"next_hop":         r.NextHop,
+			"as_path":          path,
+			"origin":           r.Origin,
+			"local_preference": int(r.LocPref),
+			"metric":           int(r.Metric),
+			"weight":           int(r.Weight),
+			"best":             r.Best,
+			"valid":            r.Valid,
+			"internal":         r.Internal,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,58 @@
+package pureport
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+const testAccDataSourceGatewayBGPRoutesConfig_basic = testAccDataSourceAwsConnectionConfig_common + `
+data "pureport_gateway_bgp_routes" "basic" {
+  connection_id = "${data.pureport_connections.main.connections.0.id}"
+}
+`
+
+func TestDataSourceGatewayBGPRoutes_basic(t *testing.T) {
+
+	resourceName := "data.pureport_gateway_bgp_routes.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGatewayBGPRoutesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile("conn-.{16}")),
+					resource.TestCheckResourceAttr(resourceName, "gateways.#", "1"),
+					resource.TestMatchResourceAttr(resourceName, "gateways.0.href", regexp.MustCompile("/gateways/.+")),
+					resource.TestCheckResourceAttrSet(resourceName, "gateways.0.routes.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestBGPRoutePrefixes(t *testing.T) {
+
+	routes := []client.BgpRoute{
+		{Network: "10.0.0.0/16", Valid: true},
+		{Network: "10.0.0.0/16", Valid: true},
+		{Network: "192.168.0.0/24", Valid: true, Internal: true},
+		{Network: "172.16.0.0/12", Valid: true, Internal: true},
+		{Network: "10.1.0.0/16", Valid: false},
+	}
+
+	learned, advertised := bgpRoutePrefixes(routes)
+
+	if !reflect.DeepEqual(learned, []string{"10.0.0.0/16"}) {
+		t.Errorf("unexpected learned prefixes: %v", learned)
+	}
+
+	if !reflect.DeepEqual(advertised, []string{"172.16.0.0/12", "192.168.0.0/24"}) {
+		t.Errorf("unexpected advertised prefixes: %v", advertised)
+	}
+}
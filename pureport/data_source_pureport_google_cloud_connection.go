@@ -28,6 +28,21 @@ func dataSourceGoogleCloudConnection() *schema.Resource {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "The Google Cloud region of the Interconnect Attachments, from the pairing key.",
+			Computed:    true,
+		},
+		"primary_edge_availability_domain": {
+			Type:        schema.TypeString,
+			Description: "The edge availability domain of the primary Interconnect Attachment, from the pairing key.",
+			Computed:    true,
+		},
+		"secondary_edge_availability_domain": {
+			Type:        schema.TypeString,
+			Description: "The edge availability domain of the secondary Interconnect Attachment, from the pairing key.",
+			Computed:    true,
+		},
 		"gateways": {
 			Computed: true,
 			Type:     schema.TypeList,
@@ -62,11 +62,14 @@ func TestDataSourceGoogleConnection_basic(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceName, "high_availability", "false"),
 						resource.TestCheckResourceAttr(resourceName, "state", "ACTIVE"),
 						resource.TestMatchResourceAttr(resourceName, "network_href", regexp.MustCompile("/networks/network-.{16}")),
+						resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
 						resource.TestCheckResourceAttr(resourceName, "cloud_service_hrefs.#", "0"),
 
 						resource.TestCheckResourceAttr(resourceName, "tags.#", "0"),
 
-						resource.TestCheckResourceAttr(resourceName, "gateways.#", "1"),
+						resource.TestCheckResourceAttr(resourceName, "gateways.#", "2"),
+						resource.TestCheckResourceAttr(resourceName, "gateways.1.id", ""),
+						resource.TestCheckResourceAttr(resourceName, "gateways.1.state", ""),
 
 						resource.TestCheckResourceAttr(resourceName, "gateways.0.availability_domain", "PRIMARY"),
 						resource.TestCheckResourceAttr(resourceName, "gateways.0.name", "GOOGLE_CLOUD_INTERCONNECT"),
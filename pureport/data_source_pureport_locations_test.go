@@ -156,6 +156,8 @@ func testAccCheckDataSourceLocationSeattle(resourceName, location string) resour
 			resource.TestCheckResourceAttr(resourceName, location+".id", "us-sea"),
 			resource.TestCheckResourceAttr(resourceName, location+".href", "/locations/us-sea"),
 			resource.TestCheckResourceAttr(resourceName, location+".name", "Seattle, WA"),
+			resource.TestCheckResourceAttrSet(resourceName, location+".latitude"),
+			resource.TestCheckResourceAttrSet(resourceName, location+".longitude"),
 			resource.TestCheckResourceAttr(resourceName, location+".links.#", "4"),
 
 			resource.TestCheckResourceAttr(resourceName, location+".links.0.location_href", "/locations/us-wdc"),
@@ -176,6 +178,8 @@ func testAccCheckDataSourceLocationSeattle(resourceName, location string) resour
 		resource.TestCheckResourceAttr(resourceName, location+".id", "us-sea"),
 		resource.TestCheckResourceAttr(resourceName, location+".href", "/locations/us-sea"),
 		resource.TestCheckResourceAttr(resourceName, location+".name", "Seattle, WA"),
+		resource.TestCheckResourceAttrSet(resourceName, location+".latitude"),
+		resource.TestCheckResourceAttrSet(resourceName, location+".longitude"),
 		resource.TestCheckResourceAttr(resourceName, location+".links.#", "0"),
 	)
 }
@@ -0,0 +1,36 @@
+package pureport
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const testAccDataSourceNearestLocationConfig_coordinates = `
+data "pureport_nearest_location" "portland" {
+  latitude  = 45.52
+  longitude = -122.68
+}
+`
+
+func TestDataSourceNearestLocation_coordinates(t *testing.T) {
+
+	resourceName := "data.pureport_nearest_location.portland"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNearestLocationConfig_coordinates,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "us-sea"),
+					resource.TestCheckResourceAttr(resourceName, "href", "/locations/us-sea"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Seattle, WA"),
+					resource.TestCheckResourceAttrSet(resourceName, "distance"),
+					resource.TestCheckResourceAttr(resourceName, "location_hrefs.0", "/locations/us-sea"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,212 @@
+package pureport
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+func dataSourceNetworkGateways() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNetworkGatewaysRead,
+
+		Schema: map[string]*schema.Schema{
+			"network_href": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"gateways": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"availability_domain": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vlan": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"customer_asn": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"customer_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pureport_asn": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"pureport_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bgp_password": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"peering_subnet": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_nat_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"unavailable_connection_ids": {
+				Type:        schema.TypeList,
+				Description: "The IDs of the connections whose gateway details could not be read.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkGatewaysRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	networkHref := d.Get("network_href").(string)
+	networkId := filepath.Base(networkHref)
+
+	ctx := config.Session.GetSessionContext()
+
+	connections, resp, err := config.Session.Client.ConnectionsApi.GetConnections(ctx, networkId)
+	if err != nil {
+		d.SetId("")
+		return fmt.Errorf("Error when Reading Network Gateways data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		d.SetId("")
+		return fmt.Errorf("Error Response while Reading Network Gateways data")
+	}
+
+	sort.Slice(connections, func(i int, j int) bool {
+		return connections[i].Name < connections[j].Name
+	})
+
+	// The connection list doesn't include the type specific gateway
+	// details (e.g. VLAN), so each connection has to be read separately.
+	// A connection that can't be read is skipped rather than failing the refresh
+	// of every other gateway in the network.
+	var gateways []map[string]interface{}
+	unavailable := []string{}
+
+	for _, c := range connections {
+
+		conn, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, c.Id)
+		if err != nil {
+			log.Printf("[WARN] Unable to read gateways for Connection %s: %s", c.Id, err)
+			unavailable = append(unavailable, c.Id)
+			continue
+		}
+
+		if resp.StatusCode >= 300 {
+			log.Printf("[WARN] Error Response while reading gateways for Connection %s: code=%v", c.Id, resp.StatusCode)
+			unavailable = append(unavailable, c.Id)
+			continue
+		}
+
+		gateways = append(gateways, flattenNetworkGateways(c.Id, c.Name, c.Type_, conn)...)
+	}
+
+	d.SetId(networkId)
+
+	if err := d.Set("gateways", gateways); err != nil {
+		return fmt.Errorf("Error setting gateways for Network %s: %s", networkId, err)
+	}
+
+	if err := d.Set("unavailable_connection_ids", unavailable); err != nil {
+		return fmt.Errorf("Error setting unavailable connections for Network %s: %s", networkId, err)
+	}
+
+	return nil
+}
+
+// flattenNetworkGateways flattens the primary and secondary gateway of any
+// connection type returned by the Pureport API.
+func flattenNetworkGateways(id string, name string, type_ string, c interface{}) (out []map[string]interface{}) {
+
+	conn := reflect.Indirect(reflect.ValueOf(c))
+	if conn.Kind() != reflect.Struct {
+		return
+	}
+
+	for _, field := range []string{"PrimaryGateway", "SecondaryGateway"} {
+
+		gateway := conn.FieldByName(field)
+		if !gateway.IsValid() || gateway.IsNil() {
+			continue
+		}
+
+		gateway = gateway.Elem()
+
+		g := map[string]interface{}{
+			"connection_id":       id,
+			"connection_name":     name,
+			"connection_type":     type_,
+			"id":                  gateway.FieldByName("Id").String(),
+			"name":                gateway.FieldByName("Name").String(),
+			"availability_domain": gateway.FieldByName("AvailabilityDomain").String(),
+			"state":               gateway.FieldByName("State").String(),
+			"vlan":                0,
+		}
+
+		if vlan := gateway.FieldByName("Vlan"); vlan.IsValid() {
+			g["vlan"] = int(vlan.Int())
+		}
+
+		if bgp := gateway.FieldByName("BgpConfig"); bgp.IsValid() && !bgp.IsNil() {
+			bgp = bgp.Elem()
+			g["customer_asn"] = int(bgp.FieldByName("CustomerASN").Int())
+			g["customer_ip"] = bgp.FieldByName("CustomerIP").String()
+			g["pureport_asn"] = int(bgp.FieldByName("PureportASN").Int())
+			g["pureport_ip"] = bgp.FieldByName("PureportIP").String()
+			g["bgp_password"] = bgp.FieldByName("Password").String()
+			g["peering_subnet"] = bgp.FieldByName("PeeringSubnet").String()
+			g["public_nat_ip"] = bgp.FieldByName("PublicNatIp").String()
+		}
+
+		out = append(out, g)
+	}
+
+	return
+}
@@ -0,0 +1,57 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccDataSourceNetworkGatewaysConfig_basic = testAccDataSourceAwsConnectionConfig_common + `
+data "pureport_network_gateways" "basic" {
+  network_href = "${data.pureport_networks.main.networks.0.href}"
+}
+`
+
+func TestDataSourceNetworkGateways_basic(t *testing.T) {
+
+	resourceName := "data.pureport_network_gateways.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNetworkGatewaysConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceNetworkGateways(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile("network-.{16}")),
+					resource.TestCheckResourceAttrSet(resourceName, "gateways.#"),
+					resource.TestCheckResourceAttr(resourceName, "unavailable_connection_ids.#", "0"),
+					resource.TestMatchResourceAttr(resourceName, "gateways.0.connection_id", regexp.MustCompile("conn-.{16}")),
+					resource.TestMatchResourceAttr(resourceName, "gateways.0.availability_domain", regexp.MustCompile("PRIMARY|SECONDARY")),
+					resource.TestMatchResourceAttr(resourceName, "gateways.0.pureport_ip", regexp.MustCompile("169.254.[0-9]{1,3}.[0-9]{1,3}/30")),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceNetworkGateways(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Network Gateways data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
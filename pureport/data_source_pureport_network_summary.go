@@ -0,0 +1,111 @@
+package pureport
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+func dataSourceNetworkSummary() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNetworkSummaryRead,
+
+		Schema: map[string]*schema.Schema{
+			"network_href": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"connection_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"connections_by_type": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"connections_by_state": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"total_speed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"failed_connection_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkSummaryRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	networkHref := d.Get("network_href").(string)
+	networkId := filepath.Base(networkHref)
+
+	ctx := config.Session.GetSessionContext()
+
+	connections, resp, err := config.Session.Client.ConnectionsApi.GetConnections(ctx, networkId)
+	if err != nil {
+		d.SetId("")
+		return fmt.Errorf("Error when Reading Network Summary data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		d.SetId("")
+		return fmt.Errorf("Error Response while Reading Network Summary data")
+	}
+
+	byType, byState, totalSpeed, failed := summarizeConnections(connections)
+
+	d.SetId(networkId)
+	d.Set("connection_count", len(connections))
+	d.Set("total_speed", totalSpeed)
+
+	if err := d.Set("connections_by_type", byType); err != nil {
+		return fmt.Errorf("Error setting connections by type for Network %s: %s", networkId, err)
+	}
+
+	if err := d.Set("connections_by_state", byState); err != nil {
+		return fmt.Errorf("Error setting connections by state for Network %s: %s", networkId, err)
+	}
+
+	if err := d.Set("failed_connection_ids", failed); err != nil {
+		return fmt.Errorf("Error setting failed connections for Network %s: %s", networkId, err)
+	}
+
+	return nil
+}
+
+// summarizeConnections counts the connections by type and state, and totals the
+// provisioned bandwidth in Mbps. Connections in a FAILED state are also returned.
+func summarizeConnections(connections []client.Connection) (byType map[string]int, byState map[string]int, totalSpeed int, failed []string) {
+
+	byType = make(map[string]int)
+	byState = make(map[string]int)
+
+	for _, c := range connections {
+		byType[c.Type_]++
+		byState[c.State]++
+		totalSpeed += int(c.Speed)
+
+		if strings.HasPrefix(c.State, "FAILED") {
+			failed = append(failed, c.Id)
+		}
+	}
+
+	sort.Strings(failed)
+
+	return
+}
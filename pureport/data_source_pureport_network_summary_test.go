@@ -0,0 +1,56 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccDataSourceNetworkSummaryConfig_basic = testAccDataSourceAwsConnectionConfig_common + `
+data "pureport_network_summary" "basic" {
+  network_href = "${data.pureport_networks.main.networks.0.href}"
+}
+`
+
+func TestDataSourceNetworkSummary_basic(t *testing.T) {
+
+	resourceName := "data.pureport_network_summary.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNetworkSummaryConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceNetworkSummary(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile("network-.{16}")),
+					resource.TestCheckResourceAttrSet(resourceName, "connection_count"),
+					resource.TestCheckResourceAttrSet(resourceName, "total_speed"),
+					resource.TestCheckResourceAttrSet(resourceName, "connections_by_type.AWS_DIRECT_CONNECT"),
+					resource.TestCheckResourceAttrSet(resourceName, "connections_by_state.ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceNetworkSummary(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Network Summary data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
@@ -20,10 +20,12 @@ func dataSourceNetworks() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"filter": filter.DataSourceFiltersSchema(),
+			"tags":   tags.TagsFilterSchema(),
 			"account_href": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Description: "The account to list the networks of. Defaults to the account_href of the provider.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"networks": {
 				Type:     schema.TypeList,
@@ -63,7 +65,10 @@ func dataSourceNetworks() *schema.Resource {
 func dataSourceNetworksRead(d *schema.ResourceData, m interface{}) error {
 
 	config := m.(*configuration.Config)
-	accountHref := d.Get("account_href").(string)
+	accountHref, err := getAccountHref(d, m)
+	if err != nil {
+		return err
+	}
 	accountId := filepath.Base(accountHref)
 
 	ctx := config.Session.GetSessionContext()
@@ -71,7 +76,7 @@ func dataSourceNetworksRead(d *schema.ResourceData, m interface{}) error {
 	networks, resp, err := config.Session.Client.NetworksApi.FindNetworks(ctx, accountId)
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error when Reading Pureport Network data: %v", err)
+		return fmt.Errorf("Error when Reading Pureport Network data: %v", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -84,6 +89,19 @@ func dataSourceNetworksRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error Response while Reading Pureport Network data")
 	}
 
+	// Only include the networks with the tags
+	if required, ok := d.GetOk("tags"); ok {
+
+		var tagged []client.Network
+		for _, x := range networks {
+			if tags.MatchTags(required.(map[string]interface{}), x.Tags) {
+				tagged = append(tagged, x)
+			}
+		}
+
+		networks = tagged
+	}
+
 	// Filter the results
 	var filteredNetworks []client.Network
 
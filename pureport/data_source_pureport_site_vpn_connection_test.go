@@ -62,6 +62,7 @@ func TestDataSourceSiteVPNConnection_basic(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceName, "high_availability", "true"),
 						resource.TestCheckResourceAttr(resourceName, "state", "ACTIVE"),
 						resource.TestMatchResourceAttr(resourceName, "network_href", regexp.MustCompile("/networks/network-.{16}")),
+						resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
 						resource.TestCheckResourceAttr(resourceName, "cloud_service_hrefs.#", "0"),
 						resource.TestCheckResourceAttr(resourceName, "tags.#", "0"),
 
@@ -0,0 +1,139 @@
+package pureport
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/filter"
+)
+
+func dataSourceSupportedPorts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSupportedPortsRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter": filter.DataSourceFiltersSchema(),
+			"account_href": {
+				Type:        schema.TypeString,
+				Description: "The account ordering the ports. Defaults to the account_href of the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"facility_href": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ports": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"speed": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"media_types": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"availability_domains": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSupportedPortsRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountHref, err := getAccountHref(d, m)
+	if err != nil {
+		return err
+	}
+	accountId := filepath.Base(accountHref)
+	facilityId := filepath.Base(d.Get("facility_href").(string))
+	filters, filtersOk := d.GetOk("filter")
+
+	ctx := config.Session.GetSessionContext()
+
+	ports, resp, err := config.Session.Client.SupportedPortsApi.GetSupportedPorts(ctx, facilityId, accountId)
+	if err != nil {
+		d.SetId("")
+		return fmt.Errorf("Error when Reading Pureport Supported Ports data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		d.SetId("")
+		return fmt.Errorf("Error Response while Reading Pureport Supported Ports data")
+	}
+
+	// Filter the results
+	var filteredPorts []client.SupportedPort
+	if filtersOk {
+
+		input := make([]interface{}, len(ports))
+		for i, x := range ports {
+			input[i] = x
+		}
+
+		output := filter.FilterType(input, filter.BuildDataSourceFilters(filters.(*schema.Set)))
+		for _, x := range output {
+			filteredPorts = append(filteredPorts, x.(client.SupportedPort))
+		}
+
+	} else {
+		filteredPorts = ports
+	}
+
+	// Sort the list
+	sort.Slice(filteredPorts, func(i int, j int) bool {
+		if filteredPorts[i].Provider != filteredPorts[j].Provider {
+			return filteredPorts[i].Provider < filteredPorts[j].Provider
+		}
+		return filteredPorts[i].Speed < filteredPorts[j].Speed
+	})
+
+	// Convert to Map
+	if err := d.Set("ports", flattenSupportedPorts(filteredPorts)); err != nil {
+		return fmt.Errorf("Error reading supported ports: %s", err)
+	}
+
+	data, err := json.Marshal(ports)
+	if err != nil {
+		return fmt.Errorf("Error generating Id: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", hashcode.String(string(data))))
+
+	return nil
+}
+
+func flattenSupportedPorts(ports []client.SupportedPort) (out []map[string]interface{}) {
+
+	for _, p := range ports {
+
+		out = append(out, map[string]interface{}{
+			"provider":             p.Provider,
+			"speed":                p.Speed,
+			"media_types":          p.MediaTypes,
+			"availability_domains": p.AvailabilityDomains,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,61 @@
+package pureport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccDataSourceSupportedPortsConfig_basic = `
+data "pureport_accounts" "main" {
+  filter {
+    name = "Name"
+    values = ["Terraform .*"]
+  }
+}
+
+data "pureport_supported_ports" "basic" {
+  account_href = "${data.pureport_accounts.main.accounts.0.href}"
+  facility_href = "/facilities/us-sea-eqx-se2"
+}
+`
+
+func TestDataSourceSupportedPorts_basic(t *testing.T) {
+
+	resourceName := "data.pureport_supported_ports.basic"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSupportedPortsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceSupportedPorts(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "ports.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "ports.0.provider"),
+					resource.TestCheckResourceAttrSet(resourceName, "ports.0.speed"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceSupportedPorts(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Supported Ports data source: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
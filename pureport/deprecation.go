@@ -0,0 +1,125 @@
+package pureport
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Renamed attributes and resources are kept for one release with a deprecation
+// warning before they are removed, so configurations can be migrated without
+// breaking. A renamed attribute is set up with renameAttribute and read with
+// getRenamed, existing State is moved to the new name with renameStateAttributes,
+// and a renamed resource is registered under its old name with deprecatedResource.
+
+// renameAttribute adds oldName to the resource as a deprecated alias of the
+// newName attribute. Setting both in a configuration is an error. When the new
+// attribute is required, either of them has to be set.
+func renameAttribute(r *schema.Resource, oldName string, newName string) {
+
+	s, ok := r.Schema[newName]
+	if !ok {
+		panic(fmt.Sprintf("renameAttribute: unknown attribute %q", newName))
+	}
+
+	old := *s
+	old.Deprecated = fmt.Sprintf("Use %s instead. %s will be removed in the next major release.", newName, oldName)
+	old.Description = fmt.Sprintf("Deprecated: use %s instead.", newName)
+
+	if s.Optional || s.Required {
+		old.Required = false
+		old.Optional = true
+		old.Default = nil
+		old.ConflictsWith = []string{newName}
+		s.ConflictsWith = append(s.ConflictsWith, oldName)
+	}
+
+	if s.Required {
+		s.Required = false
+		s.Optional = true
+
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = customdiff.All(requireOneOf(newName, oldName), r.CustomizeDiff)
+		} else {
+			r.CustomizeDiff = requireOneOf(newName, oldName)
+		}
+	}
+
+	r.Schema[oldName] = &old
+}
+
+// requireOneOf returns a CustomizeDiffFunc that fails when none of the attributes
+// are set.
+func requireOneOf(keys ...string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+
+		for _, k := range keys {
+			if !d.NewValueKnown(k) {
+				return nil
+			}
+			if _, ok := d.GetOk(k); ok {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%q: required field is not set", keys[0])
+	}
+}
+
+// getRenamed returns the value of the renamed attribute, using the deprecated
+// attribute when it is set.
+func getRenamed(d *schema.ResourceData, oldName string, newName string) interface{} {
+
+	if v, ok := d.GetOk(oldName); ok {
+		return v
+	}
+
+	return d.Get(newName)
+}
+
+// setRenamed sets the value of the renamed attribute and its deprecated alias.
+func setRenamed(d *schema.ResourceData, oldName string, newName string, value interface{}) error {
+
+	if err := d.Set(newName, value); err != nil {
+		return err
+	}
+
+	return d.Set(oldName, value)
+}
+
+// renameStateAttributes returns a StateUpgradeFunc that moves the values of the
+// renamed top level attributes in the State to their new names.
+func renameStateAttributes(renames map[string]string) schema.StateUpgradeFunc {
+	return func(rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+
+		if rawState == nil {
+			return rawState, nil
+		}
+
+		for oldName, newName := range renames {
+
+			v, ok := rawState[oldName]
+			if !ok {
+				continue
+			}
+
+			if _, ok := rawState[newName]; !ok {
+				rawState[newName] = v
+			}
+
+			delete(rawState, oldName)
+		}
+
+		return rawState, nil
+	}
+}
+
+// deprecatedResource returns the resource to register under the old name of a
+// renamed resource.
+func deprecatedResource(r *schema.Resource, replacement string) *schema.Resource {
+
+	r.DeprecationMessage = fmt.Sprintf("This resource has been renamed to %s and will be removed in the next major release. Please use %s instead.", replacement, replacement)
+
+	return r
+}
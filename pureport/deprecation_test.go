@@ -0,0 +1,112 @@
+package pureport
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func testRenamedResource() *schema.Resource {
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"location_href": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+
+	renameAttribute(r, "location", "location_href")
+
+	return r
+}
+
+func TestRenameAttribute(t *testing.T) {
+
+	r := testRenamedResource()
+
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("unexpected error validating the resource: %s", err)
+	}
+
+	old := r.Schema["location"]
+	if old.Deprecated == "" || !old.Optional || old.Required {
+		t.Errorf("expected location to be an optional deprecated attribute")
+	}
+
+	if !reflect.DeepEqual(old.ConflictsWith, []string{"location_href"}) {
+		t.Errorf("expected location to conflict with location_href, got %v", old.ConflictsWith)
+	}
+
+	if r.Schema["location_href"].Required || r.CustomizeDiff == nil {
+		t.Errorf("expected location_href to be optional and checked at plan time")
+	}
+}
+
+func TestGetRenamed(t *testing.T) {
+
+	r := testRenamedResource()
+
+	cases := []struct {
+		Config   map[string]interface{}
+		Expected string
+	}{
+		{Config: map[string]interface{}{"location_href": "/locations/us-sea"}, Expected: "/locations/us-sea"},
+		{Config: map[string]interface{}{"location": "/locations/us-ral"}, Expected: "/locations/us-ral"},
+	}
+
+	for _, c := range cases {
+
+		d := schema.TestResourceDataRaw(t, r.Schema, c.Config)
+
+		if v := getRenamed(d, "location", "location_href"); v != c.Expected {
+			t.Errorf("expected %q, got %q", c.Expected, v)
+		}
+
+		if err := setRenamed(d, "location", "location_href", c.Expected); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if d.Get("location") != c.Expected || d.Get("location_href") != c.Expected {
+			t.Errorf("expected both attributes to be set to %q", c.Expected)
+		}
+	}
+}
+
+func TestRenameStateAttributes(t *testing.T) {
+
+	upgrade := renameStateAttributes(map[string]string{"location": "location_href"})
+
+	state, err := upgrade(map[string]interface{}{
+		"id":       "conn-123",
+		"location": "/locations/us-sea",
+	}, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":            "conn-123",
+		"location_href": "/locations/us-sea",
+	}
+
+	if !reflect.DeepEqual(state, expected) {
+		t.Errorf("expected %v, got %v", expected, state)
+	}
+}
+
+func TestDeprecatedResource(t *testing.T) {
+
+	r := deprecatedResource(resourceNetwork(), "pureport_network")
+
+	if r.DeprecationMessage == "" {
+		t.Errorf("expected a deprecation message")
+	}
+
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Errorf("unexpected error validating the resource: %s", err)
+	}
+}
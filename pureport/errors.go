@@ -0,0 +1,71 @@
+package pureport
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// errorContext describes the object being operated on, e.g.
+// `pureport_aws_connection "prod" (id=conn-xxx, network=network-xxx)`
+func errorContext(name string, d *schema.ResourceData, r *schema.Resource) string {
+
+	context := name
+
+	if _, ok := r.Schema["name"]; ok {
+		if v, ok := d.Get("name").(string); ok && v != "" {
+			context = fmt.Sprintf("%s %q", context, v)
+		}
+	}
+
+	ids := []string{}
+
+	if d.Id() != "" {
+		ids = append(ids, "id="+d.Id())
+	}
+
+	if _, ok := r.Schema["network_href"]; ok {
+		if v, ok := d.Get("network_href").(string); ok && v != "" {
+			ids = append(ids, "network="+filepath.Base(v))
+		}
+	}
+
+	if len(ids) > 0 {
+		context = fmt.Sprintf("%s (%s)", context, strings.Join(ids, ", "))
+	}
+
+	return context
+}
+
+func contextCRUD(name string, operation string, r *schema.Resource, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, m interface{}) error {
+
+		// Capture the context first, as a failed create clears the ID
+		context := errorContext(name, d, r)
+
+		if err := f(d, m); err != nil {
+			return fmt.Errorf("Error during %s of %s: %s", operation, context, err)
+		}
+
+		return nil
+	}
+}
+
+// withErrorContext wraps the entry points of the resource so that returned errors
+// name the operation, resource type and the object they occurred for.
+func withErrorContext(name string, r *schema.Resource) *schema.Resource {
+
+	r.Create = contextCRUD(name, "create", r, r.Create)
+	r.Read = contextCRUD(name, "read", r, r.Read)
+	r.Update = contextCRUD(name, "update", r, r.Update)
+	r.Delete = contextCRUD(name, "delete", r, r.Delete)
+
+	return r
+}
@@ -0,0 +1,40 @@
+package pureport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestWithErrorContext(t *testing.T) {
+
+	r := withErrorContext("pureport_test", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"network_href": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return fmt.Errorf("code=500")
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":         "Test",
+		"network_href": "/networks/network-abc",
+	})
+	d.SetId("conn-123")
+
+	err := r.Delete(d, nil)
+	expected := `Error during delete of pureport_test "Test" (id=conn-123, network=network-abc): code=500`
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
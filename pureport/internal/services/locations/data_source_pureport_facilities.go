@@ -0,0 +1,175 @@
+package locations
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/filter"
+)
+
+// DataSourceFacilities returns the pureport_facilities data source.
+func DataSourceFacilities() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceFacilitiesRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter": filter.DataSourceFiltersSchema(),
+			"facilities": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vendor": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"street": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"city": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"state": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"postal_code": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"country": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"alt_ids": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFacilitiesRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	filters, filtersOk := d.GetOk("filter")
+
+	ctx := config.Session.GetSessionContext()
+
+	facilities, resp, err := config.Session.Client.FacilitiesApi.FindFacilities(ctx)
+	if err != nil {
+		d.SetId("")
+		return fmt.Errorf("Error when Reading Pureport Facility data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		d.SetId("")
+		return fmt.Errorf("Error Response while Reading Pureport Facility data")
+	}
+
+	// Filter the results
+	var filteredFacilities []client.Facility
+	if filtersOk {
+
+		input := make([]interface{}, len(facilities))
+		for i, x := range facilities {
+			input[i] = x
+		}
+
+		output := filter.FilterType(input, filter.BuildDataSourceFilters(filters.(*schema.Set)))
+		for _, x := range output {
+			filteredFacilities = append(filteredFacilities, x.(client.Facility))
+		}
+
+	} else {
+		filteredFacilities = facilities
+	}
+
+	// Sort the list
+	sort.Slice(filteredFacilities, func(i int, j int) bool {
+		return filteredFacilities[i].Id < filteredFacilities[j].Id
+	})
+
+	// Convert to Map
+	if err := d.Set("facilities", flattenFacilities(filteredFacilities)); err != nil {
+		return fmt.Errorf("Error reading facilities: %s", err)
+	}
+
+	data, err := json.Marshal(facilities)
+	if err != nil {
+		return fmt.Errorf("Error generating Id: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", hashcode.String(string(data))))
+
+	return nil
+}
+
+func flattenFacilities(facilities []client.Facility) (out []map[string]interface{}) {
+
+	for _, f := range facilities {
+
+		out = append(out, map[string]interface{}{
+			"id":      f.Id,
+			"href":    f.Href,
+			"name":    f.Name,
+			"state":   f.State,
+			"vendor":  f.Vendor,
+			"address": FlattenPhysicalAddress(f.Address),
+			"alt_ids": f.AltIds,
+		})
+	}
+
+	return
+}
+
+// FlattenPhysicalAddress flattens the address of a facility, and of the billing
+// information of an account.
+func FlattenPhysicalAddress(address *client.PhysicalAddress) (out []map[string]interface{}) {
+
+	if address == nil {
+		return
+	}
+
+	return append(out, map[string]interface{}{
+		"street":      address.Street,
+		"city":        address.City,
+		"state":       address.State,
+		"postal_code": address.PostalCode,
+		"country":     address.Country,
+	})
+}
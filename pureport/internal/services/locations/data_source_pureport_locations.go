@@ -1,4 +1,8 @@
-package pureport
+// Package locations contains the data sources of the Pureport locations and the
+// facilities within them. It is the first of the service packages the provider is
+// being split into, which share the configuration.Config of the provider as their
+// client and are registered in pureport/provider.go.
+package locations
 
 import (
 	"encoding/json"
@@ -12,7 +16,8 @@ import (
 	"github.com/pureport/terraform-provider-pureport/pureport/filter"
 )
 
-func dataSourceLocations() *schema.Resource {
+// DataSourceLocations returns the pureport_locations data source.
+func DataSourceLocations() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceLocationsRead,
 
@@ -36,6 +41,14 @@ func dataSourceLocations() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"latitude": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"longitude": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
 						"links": {
 							Type:     schema.TypeList,
 							Computed: true,
@@ -69,7 +82,7 @@ func dataSourceLocationsRead(d *schema.ResourceData, m interface{}) error {
 	locations, resp, err := config.Session.Client.LocationsApi.FindLocations(ctx)
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error when Reading Pureport Location data: %v", err)
+		return fmt.Errorf("Error when Reading Pureport Location data: %v", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -131,6 +144,11 @@ func flattenLocations(locations []client.Location) (out []map[string]interface{}
 			"links": flattenLinks(loc.LocationLinks),
 		}
 
+		if loc.GeoCoordinates != nil {
+			l["latitude"] = loc.GeoCoordinates.Latitude
+			l["longitude"] = loc.GeoCoordinates.Longitude
+		}
+
 		out = append(out, l)
 	}
 
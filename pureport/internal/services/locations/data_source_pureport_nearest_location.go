@@ -0,0 +1,212 @@
+package locations
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+// earthRadius is the mean radius of the earth in kilometers
+const earthRadius = 6371.0
+
+// DataSourceNearestLocation returns the pureport_nearest_location data source.
+func DataSourceNearestLocation() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNearestLocationRead,
+
+		Schema: map[string]*schema.Schema{
+			"latitude": {
+				Type:         schema.TypeFloat,
+				Description:  "The latitude to find the nearest location to.",
+				Optional:     true,
+				ValidateFunc: validation.FloatBetween(-90, 90),
+			},
+			"longitude": {
+				Type:         schema.TypeFloat,
+				Description:  "The longitude to find the nearest location to.",
+				Optional:     true,
+				ValidateFunc: validation.FloatBetween(-180, 180),
+			},
+			"cloud_region_id": {
+				Type:        schema.TypeString,
+				Description: "Only select locations with connections that reach the cloud region.",
+				Optional:    true,
+			},
+			"account_href": {
+				Type:        schema.TypeString,
+				Description: "The account to check the connections supported for when selecting by cloud region. Defaults to the account_href of the provider.",
+				Optional:    true,
+			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"distance": {
+				Type:        schema.TypeFloat,
+				Description: "The distance to the location in kilometers.",
+				Computed:    true,
+			},
+			"location_hrefs": {
+				Type:        schema.TypeList,
+				Description: "All matching locations, nearest first.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceNearestLocationRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	latitude, latitudeOk := d.GetOkExists("latitude")
+	longitude, longitudeOk := d.GetOkExists("longitude")
+	cloudRegionId := d.Get("cloud_region_id").(string)
+	accountHref := d.Get("account_href").(string)
+	if accountHref == "" {
+		accountHref = config.AccountHref
+	}
+
+	if latitudeOk != longitudeOk {
+		return fmt.Errorf("Both latitude and longitude have to be set")
+	}
+
+	if !latitudeOk && cloudRegionId == "" {
+		return fmt.Errorf("Either latitude and longitude, or cloud_region_id have to be set")
+	}
+
+	if cloudRegionId != "" && accountHref == "" {
+		return fmt.Errorf("account_href has to be set, on the data source or the provider, when selecting by cloud_region_id")
+	}
+
+	locations, resp, err := config.Session.Client.LocationsApi.FindLocations(ctx)
+	if err != nil {
+		return fmt.Errorf("Error when Reading Pureport Location data: %v", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while Reading Pureport Location data: code=%v", resp.StatusCode)
+	}
+
+	if cloudRegionId != "" {
+
+		supported, err := config.GetSupportedConnections(filepath.Base(accountHref))
+		if err != nil {
+			return err
+		}
+
+		locations = filterReachableLocations(locations, supported, cloudRegionId)
+	}
+
+	var distances map[string]float64
+	if latitudeOk {
+		locations, distances = sortLocationsByDistance(locations, latitude.(float64), longitude.(float64))
+	} else {
+		sort.Slice(locations, func(i int, j int) bool {
+			return locations[i].Name < locations[j].Name
+		})
+	}
+
+	if len(locations) == 0 {
+		return fmt.Errorf("No Pureport location found matching the criteria")
+	}
+
+	hrefs := make([]string, len(locations))
+	for i, l := range locations {
+		hrefs[i] = l.Href
+	}
+
+	nearest := locations[0]
+
+	d.SetId(nearest.Id)
+	d.Set("href", nearest.Href)
+	d.Set("name", nearest.Name)
+	d.Set("distance", distances[nearest.Href])
+
+	if err := d.Set("location_hrefs", hrefs); err != nil {
+		return fmt.Errorf("Error setting location hrefs: %s", err)
+	}
+
+	return nil
+}
+
+// filterReachableLocations returns the locations with supported connections that
+// reach the cloud region.
+func filterReachableLocations(locations []client.Location, supported []client.SupportedConnection, cloudRegionId string) (out []client.Location) {
+
+	reachable := make(map[string]bool)
+	for _, s := range supported {
+
+		if s.Location == nil {
+			continue
+		}
+
+		for _, r := range s.ReachableCloudRegions {
+			if filepath.Base(r.Href) == cloudRegionId {
+				reachable[s.Location.Href] = true
+			}
+		}
+	}
+
+	for _, l := range locations {
+		if reachable[l.Href] {
+			out = append(out, l)
+		}
+	}
+
+	return
+}
+
+// sortLocationsByDistance returns the locations nearest first, along with the
+// distance to each location by href. Locations without coordinates are skipped.
+func sortLocationsByDistance(locations []client.Location, latitude float64, longitude float64) (out []client.Location, distances map[string]float64) {
+
+	distances = make(map[string]float64)
+
+	for _, l := range locations {
+
+		if l.GeoCoordinates == nil {
+			continue
+		}
+
+		distance := greatCircleDistance(latitude, longitude, float64(l.GeoCoordinates.Latitude), float64(l.GeoCoordinates.Longitude))
+		distances[l.Href] = math.Round(distance*10) / 10
+
+		out = append(out, l)
+	}
+
+	sort.SliceStable(out, func(i int, j int) bool {
+		return distances[out[i].Href] < distances[out[j].Href]
+	})
+
+	return
+}
+
+// greatCircleDistance returns the distance in kilometers between two points using
+// the haversine formula.
+func greatCircleDistance(lat1, lon1, lat2, lon2 float64) float64 {
+
+	toRadians := func(deg float64) float64 {
+		return deg * math.Pi / 180
+	}
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
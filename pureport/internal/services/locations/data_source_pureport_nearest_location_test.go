@@ -0,0 +1,52 @@
+package locations
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+)
+
+func TestSortLocationsByDistance(t *testing.T) {
+
+	locations := []client.Location{
+		{Href: "/locations/us-ral", GeoCoordinates: &client.GeoCoordinates{Latitude: 35.78, Longitude: -78.64}},
+		{Href: "/locations/us-pod"},
+		{Href: "/locations/us-sea", GeoCoordinates: &client.GeoCoordinates{Latitude: 47.61, Longitude: -122.33}},
+	}
+
+	sorted, distances := sortLocationsByDistance(locations, 45.52, -122.68)
+
+	hrefs := []string{}
+	for _, l := range sorted {
+		hrefs = append(hrefs, l.Href)
+	}
+
+	expected := []string{"/locations/us-sea", "/locations/us-ral"}
+	if !reflect.DeepEqual(hrefs, expected) {
+		t.Errorf("expected %v, got %v", expected, hrefs)
+	}
+
+	// Portland to Seattle is about 234km
+	if d := distances["/locations/us-sea"]; d < 225 || d > 240 {
+		t.Errorf("unexpected distance to Seattle: %v", d)
+	}
+}
+
+func TestFilterReachableLocations(t *testing.T) {
+
+	locations := []client.Location{
+		{Href: "/locations/us-ral"},
+		{Href: "/locations/us-sea"},
+	}
+
+	supported := []client.SupportedConnection{
+		{Location: &client.Link{Href: "/locations/us-sea"}, ReachableCloudRegions: []client.Link{{Href: "/cloudRegions/aws-us-west-2"}}},
+		{Location: &client.Link{Href: "/locations/us-ral"}, ReachableCloudRegions: []client.Link{{Href: "/cloudRegions/aws-us-east-1"}}},
+	}
+
+	out := filterReachableLocations(locations, supported, "aws-us-west-2")
+	if len(out) != 1 || out[0].Href != "/locations/us-sea" {
+		t.Errorf("expected only Seattle to reach us-west-2, got %v", out)
+	}
+}
@@ -1,30 +1,47 @@
 package pureport
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+	"github.com/pureport/terraform-provider-pureport/pureport/internal/services/locations"
 )
 
 var descriptions map[string]string
 
 func init() {
 	descriptions = map[string]string{
-		"api_key":      "Pureport API Key",
-		"api_secret":   "Pureport API Secret",
-		"api_url":      "Pureport API URL to execute against",
-		"auth_profile": "The authentication profile in your local Pureport configuration file.",
+		"api_key":              "Pureport API Key",
+		"api_secret":           "Pureport API Secret",
+		"api_url":              "Pureport API URL to execute against",
+		"auth_profile":         "The authentication profile in your local Pureport configuration file.",
+		"credentials_file":     "The path to the Pureport credentials file with the authentication profiles.",
+		"account_href":         "The default account of the resources and data sources that belong to an account.",
+		"ca_bundle":            "The path to a PEM encoded CA bundle to trust when connecting to the Pureport API.",
+		"insecure_skip_verify": "Skip the verification of the TLS certificate of the Pureport API.",
+		"max_retries":          "The number of times an API request is retried after a transient failure.",
+		"request_timeout":      "The timeout of each API request, e.g. 30s or 5m.",
+		"requests_per_second":  "The maximum number of requests per second sent to the Pureport API.",
+		"poll_interval":        "The interval at which the state of asynchronous operations is polled, e.g. 10s.",
+		"default_wait_timeout": "The timeout of asynchronous operations of resources without a configured timeout, e.g. 30m.",
+		"log_requests":         "Log the metadata of every API request with a correlation ID sent to the Pureport API.",
 	}
 }
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "",
 				Description: descriptions["api_key"],
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"PUREPORT_API_KEY",
@@ -34,8 +51,8 @@ func Provider() terraform.ResourceProvider {
 			"api_secret": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "",
-				Description: descriptions["secret_key"],
+				Sensitive:   true,
+				Description: descriptions["api_secret"],
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"PUREPORT_API_SECRET",
 				}, nil),
@@ -53,16 +70,115 @@ func Provider() terraform.ResourceProvider {
 			"auth_profile": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "",
 				Description: descriptions["auth_profile"],
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"PUREPORT_PROFILE",
 				}, nil),
 			},
+
+			"credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["credentials_file"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_CREDENTIALS_FILE",
+				}, nil),
+			},
+
+			"account_href": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["account_href"],
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^/accounts/.+"), "The account_href must start with '/accounts/'."),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_ACCOUNT_HREF",
+				}, nil),
+			},
+
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["ca_bundle"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_CA_BUNDLE",
+				}, nil),
+			},
+
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: descriptions["insecure_skip_verify"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_INSECURE_SKIP_VERIFY",
+				}, false),
+			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  descriptions["max_retries"],
+				ValidateFunc: validation.IntAtLeast(0),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_MAX_RETRIES",
+				}, configuration.DefaultMaxRetries),
+			},
+
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["request_timeout"],
+				ValidateFunc: validateDuration,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_REQUEST_TIMEOUT",
+				}, nil),
+			},
+
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  descriptions["requests_per_second"],
+				ValidateFunc: validation.FloatBetween(0, 1000),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_REQUESTS_PER_SECOND",
+				}, 0.0),
+			},
+
+			"poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["poll_interval"],
+				ValidateFunc: validateDuration,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_POLL_INTERVAL",
+				}, nil),
+			},
+
+			"default_wait_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["default_wait_timeout"],
+				ValidateFunc: validateDuration,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_DEFAULT_WAIT_TIMEOUT",
+				}, nil),
+			},
+
+			"log_requests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: descriptions["log_requests"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"PUREPORT_LOG_REQUESTS",
+				}, false),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
+			"pureport_account_billing":         resourceAccountBilling(),
+			"pureport_account_invite":          resourceAccountInvite(),
+			"pureport_api_key":                 resourceAPIKey(),
 			"pureport_aws_connection":          resourceAWSConnection(),
 			"pureport_azure_connection":        resourceAzureConnection(),
+			"pureport_dummy_connection":        resourceDummyConnection(),
 			"pureport_google_cloud_connection": resourceGoogleCloudConnection(),
 			"pureport_site_vpn_connection":     resourceSiteVPNConnection(),
 			"pureport_network":                 resourceNetwork(),
@@ -70,27 +186,71 @@ func Provider() terraform.ResourceProvider {
 		DataSourcesMap: map[string]*schema.Resource{
 			"pureport_cloud_regions":           dataSourceCloudRegions(),
 			"pureport_cloud_services":          dataSourceCloudServices(),
-			"pureport_locations":               dataSourceLocations(),
+			"pureport_facilities":              locations.DataSourceFacilities(),
+			"pureport_gateway_bgp_routes":      dataSourceGatewayBGPRoutes(),
+			"pureport_locations":               locations.DataSourceLocations(),
+			"pureport_nearest_location":        locations.DataSourceNearestLocation(),
 			"pureport_networks":                dataSourceNetworks(),
+			"pureport_network_gateways":        dataSourceNetworkGateways(),
+			"pureport_network_summary":         dataSourceNetworkSummary(),
+			"pureport_supported_ports":         dataSourceSupportedPorts(),
 			"pureport_accounts":                dataSourceAccounts(),
+			"pureport_account_usage":           dataSourceAccountUsage(),
+			"pureport_account_hierarchy":       dataSourceAccountHierarchy(),
 			"pureport_connections":             dataSourceConnections(),
+			"pureport_connection_events":       dataSourceConnectionEvents(),
+			"pureport_connection_health":       dataSourceConnectionHealth(),
+			"pureport_current_key":             dataSourceCurrentKey(),
 			"pureport_aws_connection":          dataSourceAWSConnection(),
 			"pureport_azure_connection":        dataSourceAzureConnection(),
 			"pureport_google_cloud_connection": dataSourceGoogleCloudConnection(),
 			"pureport_site_vpn_connection":     dataSourceSiteVPNConnection(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+
+		meta, err := providerConfigure(d, provider.StopContext())
+		if err != nil {
+			return nil, err
+		}
+
+		// The resources are planned after the provider is configured, so the
+		// default_wait_timeout applies to resources without a configured timeout.
+		if timeout := meta.(*configuration.Config).DefaultWaitTimeout; timeout > 0 {
+			for _, r := range provider.ResourcesMap {
+				connection.SetDefaultTimeouts(r, timeout)
+			}
+		}
+
+		return meta, nil
+	}
+
+	for name, r := range provider.ResourcesMap {
+		withPanicRecovery(name, withErrorContext(name, r))
+	}
+
+	for name, r := range provider.DataSourcesMap {
+		withPanicRecovery(name, withErrorContext(name, r))
+	}
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, stopCtx context.Context) (interface{}, error) {
 
-	config := configuration.Config{}
+	config := configuration.Config{
+		StopContext: stopCtx,
+	}
 
 	if v, ok := d.GetOk("auth_profile"); ok {
 		config.AuthenticationProfile = v.(string)
 	}
 
+	if v, ok := d.GetOk("credentials_file"); ok {
+		config.CredentialsFile = v.(string)
+	}
+
 	if v, ok := d.GetOk("api_key"); ok {
 		config.APIKey = v.(string)
 	}
@@ -103,9 +263,68 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.EndPoint = v.(string)
 	}
 
+	if v, ok := d.GetOk("account_href"); ok {
+		config.AccountHref = v.(string)
+	}
+
+	if v, ok := d.GetOk("ca_bundle"); ok {
+		config.CABundle = v.(string)
+	}
+
+	if v, ok := d.GetOk("request_timeout"); ok {
+		timeout, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid request_timeout: %s", err)
+		}
+		config.RequestTimeout = timeout
+	}
+
+	config.RequestsPerSecond = d.Get("requests_per_second").(float64)
+
+	if v, ok := d.GetOk("poll_interval"); ok {
+		interval, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid poll_interval: %s", err)
+		}
+		config.PollInterval = interval
+	}
+
+	if v, ok := d.GetOk("default_wait_timeout"); ok {
+		timeout, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid default_wait_timeout: %s", err)
+		}
+		config.DefaultWaitTimeout = timeout
+	}
+
+	config.InsecureSkipVerify = d.Get("insecure_skip_verify").(bool)
+	config.LogRequests = d.Get("log_requests").(bool)
+	config.MaxRetries = d.Get("max_retries").(int)
+
 	if err := config.LoadAndValidate(); err != nil {
 		return nil, err
 	}
 
 	return &config, nil
 }
+
+func validateDuration(i interface{}, k string) (s []string, es []error) {
+
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	timeout, err := time.ParseDuration(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a duration such as 30s or 5m, got %s", k, v))
+		return
+	}
+
+	if timeout <= 0 {
+		es = append(es, fmt.Errorf("expected %s to be a positive duration, got %s", k, v))
+	}
+
+	return
+}
@@ -1,11 +1,18 @@
 package pureport
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
 	"github.com/terraform-providers/terraform-provider-aws/aws"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm"
 	"github.com/terraform-providers/terraform-provider-google/google"
@@ -44,6 +51,193 @@ func init() {
 	}
 }
 
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_impl(t *testing.T) {
+	var _ terraform.ResourceProvider = Provider()
+}
+
+// testLoginServer returns an API server that only accepts the login of the key
+func testLoginServer(key string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var login map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&login); err != nil || login["key"] != key {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"status": 401, "code": "UNAUTHORIZED", "message": "Invalid API Key"}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"access_token": "token", "expires_in": 3600}`)
+	}))
+}
+
+// testAPIServer returns an API server that accepts any login and responds to
+// requests for the paths with the JSON bodies, and with 404 to any other path.
+func testAPIServer(responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path == "/login" {
+			fmt.Fprint(w, `{"access_token": "token", "expires_in": 3600}`)
+			return
+		}
+
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status": 404, "code": "NOT_FOUND", "message": "Not found"}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+}
+
+// testAPIConfig returns the configuration of a provider using the API server
+func testAPIConfig(t *testing.T, server *httptest.Server) *configuration.Config {
+
+	raw := map[string]interface{}{
+		"api_key":     "key",
+		"api_secret":  "secret",
+		"api_url":     server.URL,
+		"max_retries": 0,
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+
+	meta, err := providerConfigure(d, context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return meta.(*configuration.Config)
+}
+
+func TestProviderConfigure(t *testing.T) {
+
+	server := testLoginServer("key")
+	defer server.Close()
+
+	raw := map[string]interface{}{
+		"api_key":             "key",
+		"api_secret":          "secret",
+		"api_url":             server.URL,
+		"auth_profile":        "test",
+		"request_timeout":     "45s",
+		"account_href":        "/accounts/ac-test",
+		"poll_interval":       "10s",
+		"requests_per_second": 5,
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+
+	meta, err := providerConfigure(d, context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	config := meta.(*configuration.Config)
+
+	if config.APIKey != "key" || config.APISecret != "secret" {
+		t.Errorf("Expected API credentials to be configured, got key=%q", config.APIKey)
+	}
+
+	if config.EndPoint != server.URL || config.Session.Configuration.EndPoint != server.URL {
+		t.Errorf("Expected endpoint to be configured, got %q", config.Session.Configuration.EndPoint)
+	}
+
+	if credentials, err := config.Session.Credentials.Get(); err != nil || credentials.SessionToken != "token" {
+		t.Errorf("Expected the session token to be acquired, got %q: %v", credentials.SessionToken, err)
+	}
+
+	if config.RequestsPerSecond != 5 {
+		t.Errorf("Expected requests per second to be configured, got %v", config.RequestsPerSecond)
+	}
+
+	if config.PollInterval != 10*time.Second || config.GetPollDelay() != 10*time.Second {
+		t.Errorf("Expected poll interval to be configured, got %s", config.PollInterval)
+	}
+
+	if config.AccountHref != "/accounts/ac-test" {
+		t.Errorf("Expected account href to be configured, got %q", config.AccountHref)
+	}
+
+	if config.RequestTimeout != 45*time.Second || config.Session.Configuration.Timeout != 45*time.Second {
+		t.Errorf("Expected request timeout to be configured, got %s", config.Session.Configuration.Timeout)
+	}
+
+	if config.Session.Configuration.AuthenticationProfile != "test" {
+		t.Errorf("Expected auth profile to be configured, got %q", config.Session.Configuration.AuthenticationProfile)
+	}
+}
+
+func TestProviderConfigure_defaultWaitTimeout(t *testing.T) {
+
+	server := testLoginServer("key")
+	defer server.Close()
+
+	raw := map[string]interface{}{
+		"api_key":              "key",
+		"api_secret":           "secret",
+		"api_url":              server.URL,
+		"max_retries":          0,
+		"default_wait_timeout": "30m",
+	}
+
+	p := Provider().(*schema.Provider)
+
+	if err := p.Configure(&terraform.ResourceConfig{Raw: raw, Config: raw}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	timeouts := p.ResourcesMap["pureport_dummy_connection"].Timeouts
+	if *timeouts.Create != 30*time.Minute || *timeouts.Delete != 30*time.Minute {
+		t.Errorf("Expected the default timeouts to be replaced, got create=%s delete=%s", *timeouts.Create, *timeouts.Delete)
+	}
+}
+
+func TestProviderConfigure_invalidCredentials(t *testing.T) {
+
+	server := testLoginServer("key")
+	defer server.Close()
+
+	raw := map[string]interface{}{
+		"api_key":    "invalid",
+		"api_secret": "secret",
+		"api_url":    server.URL,
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+
+	if _, err := providerConfigure(d, context.Background()); err == nil {
+		t.Fatalf("Expected error when the API Key is rejected")
+	}
+}
+
+func TestProviderConfigure_partialCredentials(t *testing.T) {
+
+	// Make sure the secret can't be picked up from the environment
+	if v, ok := os.LookupEnv("PUREPORT_API_SECRET"); ok {
+		os.Unsetenv("PUREPORT_API_SECRET")
+		defer os.Setenv("PUREPORT_API_SECRET", v)
+	}
+
+	raw := map[string]interface{}{
+		"api_key": "key",
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+
+	if _, err := providerConfigure(d, context.Background()); err == nil {
+		t.Fatalf("Expected error when only the API Key is configured")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 
 	pureportEnvVars := []string{
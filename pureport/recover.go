@@ -0,0 +1,71 @@
+package pureport
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// recoverPanic converts a panic in the current function into an error, so a bug
+// fails the operation instead of crashing the plugin process.
+func recoverPanic(name string, operation string, err *error) {
+	if r := recover(); r != nil {
+		log.Printf("[ERROR] Recovered from a panic while running %s for %s: %v\n%s", operation, name, r, debug.Stack())
+		*err = fmt.Errorf("Unexpected error while running %s for %s: %v. Please report this issue to the provider developers.", operation, name, r)
+	}
+}
+
+func recoverCRUD(name string, operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, m interface{}) (err error) {
+		defer recoverPanic(name, operation, &err)
+		return f(d, m)
+	}
+}
+
+func recoverCustomizeDiff(name string, f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceDiff, m interface{}) (err error) {
+		defer recoverPanic(name, "plan", &err)
+		return f(d, m)
+	}
+}
+
+func recoverImporter(name string, f schema.StateFunc) schema.StateFunc {
+
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, m interface{}) (state []*schema.ResourceData, err error) {
+		defer recoverPanic(name, "import", &err)
+		return f(d, m)
+	}
+}
+
+// withPanicRecovery wraps the entry points of the resource so that any panic is
+// returned to Terraform as an error.
+func withPanicRecovery(name string, r *schema.Resource) *schema.Resource {
+
+	r.Create = recoverCRUD(name, "create", r.Create)
+	r.Read = recoverCRUD(name, "read", r.Read)
+	r.Update = recoverCRUD(name, "update", r.Update)
+	r.Delete = recoverCRUD(name, "delete", r.Delete)
+	r.CustomizeDiff = recoverCustomizeDiff(name, r.CustomizeDiff)
+
+	if r.Importer != nil {
+		r.Importer.State = recoverImporter(name, r.Importer.State)
+	}
+
+	return r
+}
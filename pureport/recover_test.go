@@ -0,0 +1,31 @@
+package pureport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestWithPanicRecovery(t *testing.T) {
+
+	r := withPanicRecovery("pureport_test", &schema.Resource{
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			var connection map[string]interface{}
+			return connection["id"].(error)
+		},
+	})
+
+	err := r.Read(nil, nil)
+	if err == nil {
+		t.Fatalf("expected an error from a panicking read")
+	}
+
+	if !strings.Contains(err.Error(), "read for pureport_test") {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if r.Create != nil || r.CustomizeDiff != nil {
+		t.Errorf("unset functions should not be wrapped")
+	}
+}
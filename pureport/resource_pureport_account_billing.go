@@ -0,0 +1,246 @@
+package pureport
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+	"github.com/pureport/terraform-provider-pureport/pureport/internal/services/locations"
+)
+
+func resourceAccountBilling() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAccountBillingCreate,
+		Read:   resourceAccountBillingRead,
+		Update: resourceAccountBillingUpdate,
+		Delete: resourceAccountBillingDelete,
+
+		CustomizeDiff: defaultAccountHref,
+
+		Schema: map[string]*schema.Schema{
+			"account_href": {
+				Type:        schema.TypeString,
+				Description: "The account to configure billing for. Defaults to the account_href of the provider.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the billing contact for the account.",
+				Required:    true,
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Description: "The email address of the billing contact for the account.",
+				Required:    true,
+			},
+			"address": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"street": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"city": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"postal_code": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"payment_token": {
+				Type:        schema.TypeString,
+				Description: "The payment processor token used to reference the payment method for the account.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"card_last_four": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"card_expiry": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandAccountBilling(d *schema.ResourceData) client.AccountBilling {
+
+	billing := client.AccountBilling{
+		Name:        d.Get("name").(string),
+		Email:       d.Get("email").(string),
+		StripeToken: d.Get("payment_token").(string),
+		Address:     &client.PhysicalAddress{},
+		Account: &client.Link{
+			Href: d.Get("account_href").(string),
+		},
+	}
+
+	if data, ok := d.GetOk("address"); ok {
+
+		raw := data.([]interface{})
+		if len(raw) > 0 && raw[0] != nil {
+
+			address := raw[0].(map[string]interface{})
+
+			billing.Address = &client.PhysicalAddress{
+				Street:     address["street"].(string),
+				City:       address["city"].(string),
+				State:      address["state"].(string),
+				PostalCode: address["postal_code"].(string),
+				Country:    address["country"].(string),
+			}
+		}
+	}
+
+	return billing
+}
+
+func resourceAccountBillingCreate(d *schema.ResourceData, m interface{}) error {
+
+	billing := expandAccountBilling(d)
+	accountId := filepath.Base(billing.Account.Href)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.AddPaymentInformationOpts{
+		Body: optional.NewInterface(billing),
+	}
+
+	_, resp, err := config.Session.Client.BillingApi.AddPaymentInformation(
+		ctx,
+		accountId,
+		&opts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Error while creating Account Billing: err=%s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error while creating Account Billing: code=%v", resp.StatusCode)
+	}
+
+	// Billing is a singleton for the account, so the account is the ID
+	d.SetId(accountId)
+
+	return resourceAccountBillingRead(d, m)
+}
+
+func resourceAccountBillingRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountId := d.Id()
+	ctx := config.Session.GetSessionContext()
+
+	billing, resp, err := config.Session.Client.BillingApi.FindBillingForAccount(ctx, accountId)
+	if err != nil {
+		if connection.IsNotFound(resp) {
+			log.Printf("[Info] Account Billing %s not found, removing it from the State", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading data for Account Billing: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while reading Account Billing: code=%v", resp.StatusCode)
+	}
+
+	d.Set("name", billing.Name)
+	d.Set("email", billing.Email)
+	d.Set("href", billing.Href)
+	d.Set("card_last_four", billing.StripeLastFour)
+	d.Set("card_expiry", billing.StripeExpiry)
+
+	if billing.Account != nil {
+		d.Set("account_href", billing.Account.Href)
+	}
+
+	if err := d.Set("address", locations.FlattenPhysicalAddress(billing.Address)); err != nil {
+		return fmt.Errorf("Error setting address for Account Billing %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAccountBillingUpdate(d *schema.ResourceData, m interface{}) error {
+
+	billing := expandAccountBilling(d)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.UpdatePaymentInformationOpts{
+		Body: optional.NewInterface(billing),
+	}
+
+	_, resp, err := config.Session.Client.BillingApi.UpdatePaymentInformation(
+		ctx,
+		d.Id(),
+		&opts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Error while updating Account Billing: err=%s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while updating Account Billing: code=%v", resp.StatusCode)
+	}
+
+	return resourceAccountBillingRead(d, m)
+}
+
+func resourceAccountBillingDelete(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	_, resp, err := config.Session.Client.BillingApi.DeletePaymentInformation(ctx, d.Id())
+	if connection.IsNotFound(resp) {
+		log.Printf("[Info] Account Billing %s already deleted", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error deleting Account Billing: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while deleting Account Billing: code=%v", resp.StatusCode)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package pureport
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+const testAccResourceAccountBillingConfig_common = `
+data "pureport_accounts" "main" {
+  filter {
+    name = "Name"
+    values = ["Terraform Acceptance Tests"]
+  }
+}
+`
+
+const testAccResourceAccountBillingConfig_basic = testAccResourceAccountBillingConfig_common + `
+resource "pureport_account_billing" "main" {
+  account_href = "${data.pureport_accounts.main.accounts.0.href}"
+  name = "Terraform Billing"
+  email = "billing@example.com"
+
+  address {
+    street = "1 Main Street"
+    city = "Raleigh"
+    state = "NC"
+    postal_code = "27601"
+    country = "US"
+  }
+}
+`
+
+func TestResourceAccountBilling_basic(t *testing.T) {
+
+	resourceName := "pureport_account_billing.main"
+	var instance client.AccountBilling
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccountBillingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAccountBillingConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAccountBilling(resourceName, &instance),
+					resource.TestCheckResourceAttrPtr(resourceName, "href", &instance.Href),
+					resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
+					resource.TestCheckResourceAttr(resourceName, "name", "Terraform Billing"),
+					resource.TestCheckResourceAttr(resourceName, "email", "billing@example.com"),
+					resource.TestCheckResourceAttr(resourceName, "address.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "address.0.city", "Raleigh"),
+					resource.TestCheckResourceAttr(resourceName, "address.0.country", "US"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceAccountBilling(name string, instance *client.AccountBilling) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		config, ok := testAccProvider.Meta().(*configuration.Config)
+		if !ok {
+			return fmt.Errorf("Error getting Pureport client")
+		}
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Account Billing resource: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		id := rs.Primary.ID
+
+		ctx := config.Session.GetSessionContext()
+		found, resp, err := config.Session.Client.BillingApi.FindBillingForAccount(ctx, id)
+
+		if err != nil {
+			return fmt.Errorf("receive error when requesting Account Billing %s", id)
+		}
+
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("Error getting Account Billing %s: %s", id, err)
+		}
+
+		*instance = found
+
+		return nil
+	}
+}
+
+func testAccCheckAccountBillingDestroy(s *terraform.State) error {
+
+	config, ok := testAccProvider.Meta().(*configuration.Config)
+	if !ok {
+		return fmt.Errorf("Error getting Pureport client")
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "pureport_account_billing" {
+			continue
+		}
+
+		id := rs.Primary.ID
+
+		ctx := config.Session.GetSessionContext()
+		_, resp, err := config.Session.Client.BillingApi.FindBillingForAccount(ctx, id)
+
+		if err != nil && resp.StatusCode != 404 {
+			return fmt.Errorf("should not get error for Account Billing %s after delete: %s", id, err)
+		}
+	}
+
+	return nil
+}
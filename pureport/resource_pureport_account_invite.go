@@ -0,0 +1,354 @@
+package pureport
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+)
+
+const (
+	AccountInvitePending  = "PENDING"
+	AccountInviteExpired  = "EXPIRED"
+	AccountInviteAccepted = "ACCEPTED"
+)
+
+func resourceAccountInvite() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAccountInviteCreate,
+		Read:   resourceAccountInviteRead,
+		Update: resourceAccountInviteUpdate,
+		Delete: resourceAccountInviteDelete,
+
+		CustomizeDiff: defaultAccountHref,
+
+		Schema: map[string]*schema.Schema{
+			"account_href": {
+				Type:        schema.TypeString,
+				Description: "The account the user is invited to. Defaults to the account_href of the provider.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Description: "The email address the invitation is sent to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"role_hrefs": {
+				Type:        schema.TypeSet,
+				Description: "HREFs for the Account Roles granted when the invitation is accepted.",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"invited_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"invited_by_href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expired": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Description: "The state of the invitation, either PENDING, EXPIRED or ACCEPTED.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func expandAccountInvite(d *schema.ResourceData) client.AccountInvite {
+
+	invite := client.AccountInvite{
+		Email: d.Get("email").(string),
+		Account: &client.Link{
+			Href: d.Get("account_href").(string),
+		},
+		Roles: []client.Link{},
+	}
+
+	if data, ok := d.GetOk("role_hrefs"); ok {
+		for _, r := range data.(*schema.Set).List() {
+			invite.Roles = append(invite.Roles, client.Link{Href: r.(string)})
+		}
+	}
+
+	return invite
+}
+
+func resourceAccountInviteCreate(d *schema.ResourceData, m interface{}) error {
+
+	invite := expandAccountInvite(d)
+	accountId := filepath.Base(invite.Account.Href)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.InviteAccountOpts{
+		Body: optional.NewInterface(invite),
+	}
+
+	created, resp, err := config.Session.Client.AccountInvitationsApi.InviteAccount(
+		ctx,
+		accountId,
+		&opts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Error while creating Account Invite: err=%s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error while creating Account Invite: code=%v", resp.StatusCode)
+	}
+
+	if created.Id == "" {
+		return fmt.Errorf("Error decoding Account Invite ID")
+	}
+
+	d.SetId(created.Id)
+
+	return resourceAccountInviteRead(d, m)
+}
+
+func resourceAccountInviteRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountId := filepath.Base(d.Get("account_href").(string))
+	ctx := config.Session.GetSessionContext()
+
+	invite, resp, err := config.Session.Client.AccountInvitationsApi.GetAccountInvite(ctx, d.Id(), accountId)
+	if err != nil {
+		// Invitations are removed by the API once they have been accepted
+		if connection.IsNotFound(resp) {
+			return readAcceptedAccountInvite(d, m)
+		}
+		return fmt.Errorf("Error reading data for Account Invite: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while reading Account Invite: code=%v", resp.StatusCode)
+	}
+
+	d.Set("email", invite.Email)
+	d.Set("href", invite.Href)
+	d.Set("expired", invite.Expired)
+
+	if invite.Expired {
+		d.Set("state", AccountInviteExpired)
+	} else {
+		d.Set("state", AccountInvitePending)
+	}
+
+	if !invite.InvitedAt.IsZero() {
+		d.Set("invited_at", invite.InvitedAt.Format(time.RFC3339))
+	}
+
+	if invite.Account != nil {
+		d.Set("account_href", invite.Account.Href)
+	}
+
+	if invite.InvitedBy != nil {
+		d.Set("invited_by_href", invite.InvitedBy.Href)
+	}
+
+	var roleHrefs []string
+	for _, r := range invite.Roles {
+		roleHrefs = append(roleHrefs, r.Href)
+	}
+
+	if err := d.Set("role_hrefs", roleHrefs); err != nil {
+		return fmt.Errorf("Error setting roles for Account Invite %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// readAcceptedAccountInvite keeps an invitation that is no longer found as
+// accepted while its email belongs to a member of the account, so it isn't sent
+// again. It's only removed from the State when there is no such member.
+func readAcceptedAccountInvite(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountId := filepath.Base(d.Get("account_href").(string))
+
+	member, err := findAccountMember(config, accountId, d.Get("email").(string))
+	if err != nil {
+		return err
+	}
+
+	if member == nil {
+		log.Printf("[Info] Account Invite %s not found, removing it from the State", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[Info] Account Invite %s was accepted by %s", d.Id(), member.User.Href)
+
+	d.Set("expired", false)
+	d.Set("state", AccountInviteAccepted)
+
+	return nil
+}
+
+// findAccountMember returns the member of the account with the email, or nil
+// when no member has the email.
+func findAccountMember(config *configuration.Config, accountId string, email string) (*client.AccountMember, error) {
+
+	ctx := config.Session.GetSessionContext()
+
+	members, resp, err := config.Session.Client.AccountMembersApi.FindAccountMembers(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading members of Account %s: %s", accountId, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Error Response while reading members of Account %s: code=%v", accountId, resp.StatusCode)
+	}
+
+	for i, member := range members {
+
+		if member.User == nil {
+			continue
+		}
+
+		userId := filepath.Base(member.User.Href)
+
+		user, resp, err := config.Session.Client.UsersApi.GetUser(ctx, userId)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading User %s: %s", userId, configuration.APIError(err))
+		}
+
+		if resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("Error Response while reading User %s: code=%v", userId, resp.StatusCode)
+		}
+
+		if strings.EqualFold(user.Email, email) {
+			return &members[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func resourceAccountInviteUpdate(d *schema.ResourceData, m interface{}) error {
+
+	if d.Get("state").(string) == AccountInviteAccepted {
+		return updateAccountMemberRoles(d, m)
+	}
+
+	invite := expandAccountInvite(d)
+	invite.Id = d.Id()
+	accountId := filepath.Base(invite.Account.Href)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.UpdateAccountInviteOpts{
+		Body: optional.NewInterface(invite),
+	}
+
+	_, resp, err := config.Session.Client.AccountInvitationsApi.UpdateAccountInvite(
+		ctx,
+		d.Id(),
+		accountId,
+		&opts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Error while updating Account Invite: err=%s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while updating Account Invite: code=%v", resp.StatusCode)
+	}
+
+	return resourceAccountInviteRead(d, m)
+}
+
+// updateAccountMemberRoles changes the roles of the member that accepted the
+// invitation, as the invitation itself no longer exists.
+func updateAccountMemberRoles(d *schema.ResourceData, m interface{}) error {
+
+	invite := expandAccountInvite(d)
+	accountId := filepath.Base(invite.Account.Href)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	member, err := findAccountMember(config, accountId, invite.Email)
+	if err != nil {
+		return err
+	}
+
+	if member == nil {
+		return fmt.Errorf("Error updating Account Invite %s: no member of Account %s has the email %s", d.Id(), accountId, invite.Email)
+	}
+
+	member.Roles = invite.Roles
+
+	opts := client.UpdateAccountMemberOpts{
+		Body: optional.NewInterface(*member),
+	}
+
+	_, resp, err := config.Session.Client.AccountMembersApi.UpdateAccountMember(
+		ctx,
+		filepath.Base(member.User.Href),
+		accountId,
+		&opts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Error while updating the roles of Account Member %s: err=%s", member.User.Href, configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while updating the roles of Account Member %s: code=%v", member.User.Href, resp.StatusCode)
+	}
+
+	return resourceAccountInviteRead(d, m)
+}
+
+func resourceAccountInviteDelete(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountId := filepath.Base(d.Get("account_href").(string))
+	ctx := config.Session.GetSessionContext()
+
+	resp, err := config.Session.Client.AccountInvitationsApi.DeleteAccountInvite(ctx, d.Id(), accountId)
+	if err != nil {
+		// Nothing left to revoke if the invitation was accepted in the meantime
+		if connection.IsNotFound(resp) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error deleting Account Invite: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while deleting Account Invite: code=%v", resp.StatusCode)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package pureport
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+const testAccResourceAccountInviteConfig_basic = `
+data "pureport_accounts" "main" {
+  filter {
+    name = "Name"
+    values = ["Terraform"]
+  }
+}
+
+resource "pureport_account_invite" "main" {
+  account_href = "${data.pureport_accounts.main.accounts.0.href}"
+  email = "terraform-acc-invite@example.com"
+}
+`
+
+func TestResourceAccountInvite_basic(t *testing.T) {
+
+	resourceName := "pureport_account_invite.main"
+	var instance client.AccountInvite
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccountInviteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAccountInviteConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAccountInvite(resourceName, &instance),
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Id),
+					resource.TestCheckResourceAttrPtr(resourceName, "href", &instance.Href),
+					resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
+					resource.TestCheckResourceAttr(resourceName, "email", "terraform-acc-invite@example.com"),
+					resource.TestCheckResourceAttr(resourceName, "expired", "false"),
+					resource.TestCheckResourceAttr(resourceName, "state", "PENDING"),
+					resource.TestCheckResourceAttrSet(resourceName, "invited_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceAccountInvite(name string, instance *client.AccountInvite) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		config, ok := testAccProvider.Meta().(*configuration.Config)
+		if !ok {
+			return fmt.Errorf("Error getting Pureport client")
+		}
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Account Invite resource: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		id := rs.Primary.ID
+		accountId := filepath.Base(rs.Primary.Attributes["account_href"])
+
+		ctx := config.Session.GetSessionContext()
+		found, resp, err := config.Session.Client.AccountInvitationsApi.GetAccountInvite(ctx, id, accountId)
+
+		if err != nil {
+			return fmt.Errorf("receive error when requesting Account Invite %s", id)
+		}
+
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("Error getting Account Invite %s: %s", id, err)
+		}
+
+		*instance = found
+
+		return nil
+	}
+}
+
+func testAccCheckAccountInviteDestroy(s *terraform.State) error {
+
+	config, ok := testAccProvider.Meta().(*configuration.Config)
+	if !ok {
+		return fmt.Errorf("Error getting Pureport client")
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "pureport_account_invite" {
+			continue
+		}
+
+		id := rs.Primary.ID
+		accountId := filepath.Base(rs.Primary.Attributes["account_href"])
+
+		ctx := config.Session.GetSessionContext()
+		_, resp, err := config.Session.Client.AccountInvitationsApi.GetAccountInvite(ctx, id, accountId)
+
+		if err != nil && resp.StatusCode != 404 {
+			return fmt.Errorf("should not get error for Account Invite %s after delete: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
+func TestResourceAccountInviteRead_accepted(t *testing.T) {
+
+	cases := []struct {
+		Name    string
+		Email   string
+		Removed bool
+	}{
+		{Name: "accepted", Email: "Operator@example.com"},
+		{Name: "removed", Email: "other@example.com", Removed: true},
+	}
+
+	server := testAPIServer(map[string]string{
+		"/accounts/ac-test/members": `[{"user": {"href": "/users/user-1"}, "account": {"href": "/accounts/ac-test"}}]`,
+		"/users/user-1":             `{"id": "user-1", "href": "/users/user-1", "email": "operator@example.com"}`,
+	})
+	defer server.Close()
+
+	config := testAPIConfig(t, server)
+
+	for _, c := range cases {
+
+		d := schema.TestResourceDataRaw(t, resourceAccountInvite().Schema, map[string]interface{}{
+			"account_href": "/accounts/ac-test",
+			"email":        c.Email,
+		})
+		d.SetId("invite-1")
+
+		if err := resourceAccountInviteRead(d, config); err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		if c.Removed {
+			if d.Id() != "" {
+				t.Errorf("%s: expected the invite to be removed from the State", c.Name)
+			}
+			continue
+		}
+
+		if d.Id() != "invite-1" || d.Get("state") != AccountInviteAccepted {
+			t.Errorf("%s: expected the invite to be kept as accepted, got id %q with state %q", c.Name, d.Id(), d.Get("state"))
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package pureport
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+)
+
+func resourceAPIKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAPIKeyCreate,
+		Read:   resourceAPIKeyRead,
+		Update: resourceAPIKeyUpdate,
+		Delete: resourceAPIKeyDelete,
+
+		CustomizeDiff: customdiff.All(
+			defaultAccountHref,
+			resourceAPIKeyCustomizeDiff,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"account_href": {
+				Type:        schema.TypeString,
+				Description: "The account of the API Key. Defaults to the account_href of the provider.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"role_hrefs": {
+				Type:        schema.TypeSet,
+				Description: "HREFs for the Account Roles that restrict what this API Key is permitted to do.",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"rotation_triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, will force a new API Key to be created.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"expires_at": {
+				Type:         schema.TypeString,
+				Description:  "RFC3339 timestamp after which the API Key is considered expired and will be replaced.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"expired": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"remaining_validity": {
+				Type:        schema.TypeInt,
+				Description: "The number of seconds remaining before the API Key expires.",
+				Computed:    true,
+			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func expandAPIKey(d *schema.ResourceData) client.ApiKey {
+
+	key := client.ApiKey{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Account: &client.Link{
+			Href: d.Get("account_href").(string),
+		},
+	}
+
+	if data, ok := d.GetOk("role_hrefs"); ok {
+		for _, r := range data.(*schema.Set).List() {
+			key.Roles = append(key.Roles, client.Link{Href: r.(string)})
+		}
+	}
+
+	return key
+}
+
+// apiKeyRemainingValidity returns the time left before the configured expiration
+// of the API Key. Keys without an expiration never expire.
+func apiKeyRemainingValidity(expiresAt string) (time.Duration, bool, error) {
+
+	if expiresAt == "" {
+		return 0, false, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return 0, false, fmt.Errorf("Error parsing API Key expiration: %s", err)
+	}
+
+	return time.Until(expiration), true, nil
+}
+
+// resourceAPIKeyCustomizeDiff rejects the plan while expires_at has passed, as a
+// key can't be created with it. Moving expires_at to a future time replaces an
+// expired key, since changing expires_at forces a new key.
+func resourceAPIKeyCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+
+	if !d.NewValueKnown("expires_at") {
+		return nil
+	}
+
+	expiresAt := d.Get("expires_at").(string)
+
+	remaining, ok, err := apiKeyRemainingValidity(expiresAt)
+	if err != nil || !ok || remaining > 0 {
+		return err
+	}
+
+	if d.Id() == "" {
+		return fmt.Errorf("expires_at %s is in the past, set it to a future time to create the API Key", expiresAt)
+	}
+
+	return fmt.Errorf("API Key %s expired at %s, set expires_at to a future time to replace it", d.Id(), expiresAt)
+}
+
+func resourceAPIKeyCreate(d *schema.ResourceData, m interface{}) error {
+
+	remaining, ok, err := apiKeyRemainingValidity(d.Get("expires_at").(string))
+	if err != nil {
+		return err
+	}
+
+	if ok && remaining <= 0 {
+		return fmt.Errorf("Error while creating API Key: expires_at %s is in the past", d.Get("expires_at"))
+	}
+
+	key := expandAPIKey(d)
+	accountId := filepath.Base(key.Account.Href)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.CreateApiKeyOpts{
+		Body: optional.NewInterface(key),
+	}
+
+	created, resp, err := config.Session.Client.ApikeysApi.CreateApiKey(
+		ctx,
+		accountId,
+		&opts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Error while creating API Key: err=%s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error while creating API Key: code=%v", resp.StatusCode)
+	}
+
+	if created.Key == "" {
+		return fmt.Errorf("Error decoding API Key ID")
+	}
+
+	d.SetId(created.Key)
+
+	// The secret is only returned when the key is first created so it
+	// has to be saved here rather than during the Read.
+	d.Set("secret", created.Secret)
+
+	return resourceAPIKeyRead(d, m)
+}
+
+func resourceAPIKeyRead(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountId := filepath.Base(d.Get("account_href").(string))
+	ctx := config.Session.GetSessionContext()
+
+	key, resp, err := config.Session.Client.ApikeysApi.GetApiKey(ctx, d.Id(), accountId)
+	if err != nil {
+		if connection.IsNotFound(resp) {
+			log.Printf("[Info] API Key %s not found, removing it from the State", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading data for API Key: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while reading API Key: code=%v", resp.StatusCode)
+	}
+
+	d.Set("name", key.Name)
+	d.Set("description", key.Description)
+	d.Set("href", key.Href)
+	d.Set("key", key.Key)
+
+	if key.Account != nil {
+		d.Set("account_href", key.Account.Href)
+	}
+
+	var roleHrefs []string
+	for _, r := range key.Roles {
+		roleHrefs = append(roleHrefs, r.Href)
+	}
+
+	if err := d.Set("role_hrefs", roleHrefs); err != nil {
+		return fmt.Errorf("Error setting roles for API Key %s: %s", d.Id(), err)
+	}
+
+	remaining, ok, err := apiKeyRemainingValidity(d.Get("expires_at").(string))
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		if remaining < 0 {
+			remaining = 0
+		}
+		d.Set("remaining_validity", int(remaining.Seconds()))
+		d.Set("expired", remaining == 0)
+	} else {
+		d.Set("remaining_validity", 0)
+		d.Set("expired", false)
+	}
+
+	return nil
+}
+
+func resourceAPIKeyUpdate(d *schema.ResourceData, m interface{}) error {
+
+	key := expandAPIKey(d)
+	key.Key = d.Id()
+	accountId := filepath.Base(key.Account.Href)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.UpdateApiKeyOpts{
+		Body: optional.NewInterface(key),
+	}
+
+	_, resp, err := config.Session.Client.ApikeysApi.UpdateApiKey(
+		ctx,
+		d.Id(),
+		accountId,
+		&opts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Error while updating API Key: err=%s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while updating API Key: code=%v", resp.StatusCode)
+	}
+
+	return resourceAPIKeyRead(d, m)
+}
+
+func resourceAPIKeyDelete(d *schema.ResourceData, m interface{}) error {
+
+	config := m.(*configuration.Config)
+	accountId := filepath.Base(d.Get("account_href").(string))
+	ctx := config.Session.GetSessionContext()
+
+	resp, err := config.Session.Client.ApikeysApi.DeleteApiKey(ctx, d.Id(), accountId)
+	if connection.IsNotFound(resp) {
+		log.Printf("[Info] API Key %s already deleted", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error deleting API Key: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error Response while deleting API Key: code=%v", resp.StatusCode)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package pureport
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+const testAccResourceAPIKeyConfig_common = `
+data "pureport_accounts" "main" {
+  filter {
+    name = "Name"
+    values = ["Terraform"]
+  }
+}
+`
+
+const testAccResourceAPIKeyConfig_basic = testAccResourceAPIKeyConfig_common + `
+resource "pureport_api_key" "main" {
+  name = "ApiKeyTest"
+  description = "API Key Terraform Test"
+  account_href = "${data.pureport_accounts.main.accounts.0.href}"
+
+  rotation_triggers = {
+    rotation = "1"
+  }
+
+  expires_at = "2099-01-01T00:00:00Z"
+}
+`
+
+const testAccResourceAPIKeyConfig_rotated = testAccResourceAPIKeyConfig_common + `
+resource "pureport_api_key" "main" {
+  name = "ApiKeyTest"
+  description = "API Key Terraform Test"
+  account_href = "${data.pureport_accounts.main.accounts.0.href}"
+
+  rotation_triggers = {
+    rotation = "2"
+  }
+
+  expires_at = "2099-01-01T00:00:00Z"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`
+
+func TestResourceAPIKey_basic(t *testing.T) {
+
+	resourceName := "pureport_api_key.main"
+	var instance client.ApiKey
+	var rotated client.ApiKey
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAPIKeyConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAPIKey(resourceName, &instance),
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Key),
+					resource.TestCheckResourceAttrPtr(resourceName, "key", &instance.Key),
+					resource.TestCheckResourceAttr(resourceName, "name", "ApiKeyTest"),
+					resource.TestCheckResourceAttr(resourceName, "description", "API Key Terraform Test"),
+					resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
+					resource.TestCheckResourceAttrSet(resourceName, "secret"),
+					resource.TestCheckResourceAttr(resourceName, "expires_at", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(resourceName, "expired", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "role_hrefs.#"),
+				),
+			},
+			{
+				Config: testAccResourceAPIKeyConfig_rotated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAPIKey(resourceName, &rotated),
+					TestCheckResourceConnectionIdChanged(&instance.Key, &rotated.Key),
+					resource.TestCheckResourceAttrSet(resourceName, "secret"),
+					resource.TestCheckResourceAttr(resourceName, "expires_at", "2099-01-01T00:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
+func TestAPIKeyRemainingValidity(t *testing.T) {
+
+	cases := []struct {
+		ExpiresAt string
+		Ok        bool
+		Expired   bool
+		Error     bool
+	}{
+		{ExpiresAt: ""},
+		{ExpiresAt: "2099-01-01T00:00:00Z", Ok: true},
+		{ExpiresAt: "2019-01-01T00:00:00Z", Ok: true, Expired: true},
+		{ExpiresAt: "2019-01-01", Error: true},
+	}
+
+	for _, c := range cases {
+
+		remaining, ok, err := apiKeyRemainingValidity(c.ExpiresAt)
+
+		if (err != nil) != c.Error {
+			t.Errorf("%q: expected error %t, got %v", c.ExpiresAt, c.Error, err)
+			continue
+		}
+
+		if ok != c.Ok {
+			t.Errorf("%q: expected ok %t, got %t", c.ExpiresAt, c.Ok, ok)
+		}
+
+		if ok && (remaining <= 0) != c.Expired {
+			t.Errorf("%q: expected expired %t, got a remaining validity of %s", c.ExpiresAt, c.Expired, remaining)
+		}
+	}
+}
+
+func TestResourceAPIKeyCustomizeDiff(t *testing.T) {
+
+	cases := []struct {
+		Name      string
+		State     string
+		ExpiresAt string
+		New       bool
+		Replace   bool
+		Error     bool
+	}{
+		{Name: "no expiration"},
+		{Name: "valid", State: "2099-01-01T00:00:00Z", ExpiresAt: "2099-01-01T00:00:00Z"},
+		{Name: "expired", State: "2019-01-01T00:00:00Z", ExpiresAt: "2019-01-01T00:00:00Z", Error: true},
+		{Name: "expired and moved", State: "2019-01-01T00:00:00Z", ExpiresAt: "2099-01-01T00:00:00Z", Replace: true},
+		{Name: "new and expired", ExpiresAt: "2019-01-01T00:00:00Z", New: true, Error: true},
+		{Name: "new", ExpiresAt: "2099-01-01T00:00:00Z", New: true},
+	}
+
+	for _, c := range cases {
+
+		raw := map[string]interface{}{
+			"name":         "ApiKeyTest",
+			"account_href": "/accounts/ac-123",
+		}
+
+		if c.ExpiresAt != "" {
+			raw["expires_at"] = c.ExpiresAt
+		}
+
+		var state *terraform.InstanceState
+		if !c.New {
+			state = &terraform.InstanceState{
+				ID: "key-123",
+				Attributes: map[string]string{
+					"id":           "key-123",
+					"name":         "ApiKeyTest",
+					"account_href": "/accounts/ac-123",
+					"key":          "key-123",
+					"expired":      "false",
+				},
+			}
+
+			if c.State != "" {
+				state.Attributes["expires_at"] = c.State
+			}
+		}
+
+		diff, err := resourceAPIKey().Diff(state, &terraform.ResourceConfig{Raw: raw, Config: raw}, &configuration.Config{})
+
+		if c.Error {
+			if err == nil {
+				t.Errorf("%s: expected an error", c.Name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.Name, err)
+			continue
+		}
+
+		if !c.New && diff.RequiresNew() != c.Replace {
+			t.Errorf("%s: expected replacement %t, got %t", c.Name, c.Replace, diff.RequiresNew())
+		}
+	}
+}
+
+func testAccCheckResourceAPIKey(name string, instance *client.ApiKey) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		config, ok := testAccProvider.Meta().(*configuration.Config)
+		if !ok {
+			return fmt.Errorf("Error getting Pureport client")
+		}
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find API Key resource: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		id := rs.Primary.ID
+		accountId := filepath.Base(rs.Primary.Attributes["account_href"])
+
+		ctx := config.Session.GetSessionContext()
+		found, resp, err := config.Session.Client.ApikeysApi.GetApiKey(ctx, id, accountId)
+
+		if err != nil {
+			return fmt.Errorf("receive error when requesting API Key %s", id)
+		}
+
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("Error getting API Key %s: %s", id, err)
+		}
+
+		*instance = found
+
+		return nil
+	}
+}
+
+func testAccCheckAPIKeyDestroy(s *terraform.State) error {
+
+	config, ok := testAccProvider.Meta().(*configuration.Config)
+	if !ok {
+		return fmt.Errorf("Error getting Pureport client")
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "pureport_api_key" {
+			continue
+		}
+
+		id := rs.Primary.ID
+		accountId := filepath.Base(rs.Primary.Attributes["account_href"])
+
+		ctx := config.Session.GetSessionContext()
+		_, resp, err := config.Session.Client.ApikeysApi.GetApiKey(ctx, id, accountId)
+
+		if err != nil && resp.StatusCode != 404 {
+			return fmt.Errorf("should not get error for API Key %s after delete: %s", id, err)
+		}
+	}
+
+	return nil
+}
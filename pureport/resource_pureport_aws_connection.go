@@ -6,11 +6,11 @@ import (
 	"net/url"
 	"path/filepath"
 	"sort"
-	"time"
+	"strings"
 
 	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/structure"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/pureport/pureport-sdk-go/pureport/client"
 	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
@@ -31,12 +31,6 @@ func resourceAWSConnection() *schema.Resource {
 			Required: true,
 			ForceNew: true,
 		},
-		"speed": {
-			Type:         schema.TypeInt,
-			Required:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.IntInSlice([]int{50, 100, 200, 300, 400, 500, 1000, 10000}),
-		},
 		"cloud_service_hrefs": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -72,19 +66,47 @@ func resourceAWSConnection() *schema.Resource {
 		Update: resourceAWSConnectionUpdate,
 		Delete: resourceAWSConnectionDelete,
 
+		CustomizeDiff: customdiff.All(
+			resourceAWSConnectionCustomizeDiff,
+			connection.ValidateHighAvailabilityDowngrade,
+			connection.ForceNewSpeed("AWS_DIRECT_CONNECT"),
+			connection.ValidateSpeed("AWS_DIRECT_CONNECT"),
+			connection.ValidateBillingTerm("AWS_DIRECT_CONNECT"),
+			connection.EstimateCost("AWS_DIRECT_CONNECT"),
+		),
+
+		Importer: &schema.ResourceImporter{
+			State: connection.ImportConnection(connection.AwsConnectionName),
+		},
+
 		Schema: connection_schema,
 
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(6 * time.Minute),
-			Delete: schema.DefaultTimeout(6 * time.Minute),
+			Create: schema.DefaultTimeout(connection.DefaultTimeout),
+			Update: schema.DefaultTimeout(connection.DefaultTimeout),
+			Delete: schema.DefaultTimeout(connection.DefaultTimeout),
 		},
 	}
 }
 
+func resourceAWSConnectionCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+
+	// Public peering is only useful with the AWS services that should be reachable
+	if strings.ToUpper(d.Get("peering_type").(string)) != "PUBLIC" || !d.NewValueKnown("cloud_service_hrefs") {
+		return nil
+	}
+
+	if len(d.Get("cloud_service_hrefs").([]interface{})) == 0 {
+		return fmt.Errorf("%s with PUBLIC peering requires at least one cloud service in cloud_service_hrefs. Use the pureport_cloud_services data source to find the services available in %s.", connection.AwsConnectionName, d.Get("aws_region"))
+	}
+
+	return nil
+}
+
 func expandAWSConnection(d *schema.ResourceData) client.AwsDirectConnectConnection {
 
 	// Generic Connection values
-	speed := d.Get("speed").(int)
+	speed := connection.GetSpeed(d)
 
 	// Create the body of the request
 	c := client.AwsDirectConnectConnection{
@@ -124,6 +146,16 @@ func expandAWSConnection(d *schema.ResourceData) client.AwsDirectConnectConnecti
 
 func resourceAWSConnectionCreate(d *schema.ResourceData, m interface{}) error {
 
+	if err := connection.ProvisionConnection(connection.AwsConnectionName, d, m, resourceAWSConnectionAdd); err != nil {
+		return err
+	}
+
+	return resourceAWSConnectionRead(d, m)
+}
+
+// resourceAWSConnectionAdd requests the new connection from the API
+func resourceAWSConnectionAdd(d *schema.ResourceData, m interface{}) error {
+
 	c := expandAWSConnection(d)
 
 	config := m.(*configuration.Config)
@@ -140,28 +172,10 @@ func resourceAWSConnectionCreate(d *schema.ResourceData, m interface{}) error {
 	)
 
 	if err != nil {
-
-		http_err := err
-		json_response := string(err.(client.GenericSwaggerError).Body()[:])
-		response, err := structure.ExpandJsonFromString(json_response)
-
-		if err != nil {
-			log.Printf("Error Creating new %s: %v", connection.AwsConnectionName, err)
-
-		} else {
-			statusCode := int(response["status"].(float64))
-
-			log.Printf("Error Creating new %s: %d\n", connection.AwsConnectionName, statusCode)
-			log.Printf("  %s\n", response["code"])
-			log.Printf("  %s\n", response["message"])
-		}
-
-		d.SetId("")
-		return fmt.Errorf("Error while creating %s: err=%s", connection.AwsConnectionName, http_err)
+		return connection.CreateError(connection.AwsConnectionName, resp, err)
 	}
 
 	if resp.StatusCode >= 300 {
-		d.SetId("")
 		return fmt.Errorf("Error while creating %s: code=%v", connection.AwsConnectionName, resp.StatusCode)
 	}
 
@@ -179,43 +193,30 @@ func resourceAWSConnectionCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error decoding Connection ID")
 	}
 
-	if err := connection.WaitForConnection(connection.AwsConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.AwsConnectionName, err)
-	}
-
-	return resourceAWSConnectionRead(d, m)
+	return nil
 }
 
 func resourceAWSConnectionRead(d *schema.ResourceData, m interface{}) error {
 
-	config := m.(*configuration.Config)
-	connectionId := d.Id()
-	ctx := config.Session.GetSessionContext()
+	c, err := connection.ReadConnection(connection.AwsConnectionName, d, m)
+	if err != nil || c == nil {
+		return err
+	}
 
-	c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			log.Printf("Error Response while reading %s: code=%v", connection.AwsConnectionName, resp.StatusCode)
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("Error reading data for %s: %s", connection.AwsConnectionName, err)
+	conn, ok := c.(client.AwsDirectConnectConnection)
+	if !ok {
+		return fmt.Errorf("Error reading %s %s: connection is not a %s", connection.AwsConnectionName, d.Id(), connection.AwsConnectionName)
 	}
 
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while reading %s: code=%v", connection.AwsConnectionName, resp.StatusCode)
+	if err := connection.FlattenConnection(connection.AwsConnectionName, d, conn); err != nil {
+		return err
 	}
 
-	conn := c.(client.AwsDirectConnectConnection)
+	connection.ReadConnectionAccount(connection.AwsConnectionName, d, m)
+
 	d.Set("aws_account_id", conn.AwsAccountId)
 	d.Set("aws_region", conn.AwsRegion)
-	d.Set("description", conn.Description)
-	d.Set("high_availability", conn.HighAvailability)
-	d.Set("href", conn.Href)
-	d.Set("name", conn.Name)
 	d.Set("peering_type", conn.Peering.Type_)
-	d.Set("speed", conn.Speed)
-	d.Set("state", conn.State)
 
 	var cloudServiceHrefs []string
 	for _, cs := range conn.CloudServices {
@@ -230,35 +231,6 @@ func resourceAWSConnectionRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error setting cloud services for %s %s: %s", connection.AwsConnectionName, d.Id(), err)
 	}
 
-	if err := d.Set("customer_networks", connection.FlattenCustomerNetworks(conn.CustomerNetworks)); err != nil {
-		return fmt.Errorf("Error setting customer networks for %s %s: %s", connection.AwsConnectionName, d.Id(), err)
-	}
-
-	// NAT Configuration
-	if err := d.Set("nat_config", connection.FlattenNatConfig(conn.Nat)); err != nil {
-		return fmt.Errorf("Error setting NAT Configuration for %s %s: %s", connection.AwsConnectionName, d.Id(), err)
-	}
-
-	// Add Gateway information
-	var gateways []map[string]interface{}
-	if g := conn.PrimaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenStandardGateway(g))
-	}
-	if g := conn.SecondaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenStandardGateway(g))
-	}
-	if err := d.Set("gateways", gateways); err != nil {
-		return fmt.Errorf("Error setting gateway information for %s %s: %s", connection.AwsConnectionName, d.Id(), err)
-	}
-
-	if err := d.Set("location_href", conn.Location.Href); err != nil {
-		return fmt.Errorf("Error setting location for %s %s: %s", connection.AwsConnectionName, d.Id(), err)
-	}
-
-	if err := d.Set("network_href", conn.Network.Href); err != nil {
-		return fmt.Errorf("Error setting network for %s %s: %s", connection.AwsConnectionName, d.Id(), err)
-	}
-
 	if err := d.Set("tags", conn.Tags); err != nil {
 		return fmt.Errorf("Error setting tags for %s %s: %s", connection.AwsConnectionName, d.Id(), err)
 	}
@@ -266,89 +238,23 @@ func resourceAWSConnectionRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// resourceAWSConnectionUpdate updates the connection in place. The speed, AWS
+// account, region and peering type of a hosted connection can't be changed, so
+// changing them forces a new connection instead.
 func resourceAWSConnectionUpdate(d *schema.ResourceData, m interface{}) error {
 
 	c := expandAWSConnection(d)
 
-	d.Partial(true)
-
-	config := m.(*configuration.Config)
-	ctx := config.Session.GetSessionContext()
-
-	if d.HasChange("name") {
-		c.Name = d.Get("name").(string)
-		d.SetPartial("name")
-	}
-
-	if d.HasChange("description") {
-		c.Description = d.Get("description").(string)
-		d.SetPartial("description")
-	}
-
-	if d.HasChange("speed") {
-		c.Speed = int32(d.Get("speed").(int))
-		d.SetPartial("speed")
-	}
-
-	if d.HasChange("customer_networks") {
-		c.CustomerNetworks = connection.ExpandCustomerNetworks(d)
-	}
-
-	if d.HasChange("nat_config") {
-		c.Nat = connection.ExpandNATConfiguration(d)
-	}
-
-	if d.HasChange("billing_term") {
-		c.BillingTerm = d.Get("billing_term").(string)
-	}
-
-	if d.HasChange("cloud_services") {
-		c.CloudServices = connection.ExpandCloudServices(d)
-	}
-
+	// Removing all tags has to send an empty map, not omit the tags
 	if d.HasChange("tags") {
 		_, nraw := d.GetChange("tags")
 		c.Tags = tags.FilterTags(nraw.(map[string]interface{}))
 	}
 
-	opts := client.UpdateConnectionOpts{
-		Body: optional.NewInterface(c),
-	}
-
-	_, resp, err := config.Session.Client.ConnectionsApi.UpdateConnection(
-		ctx,
-		d.Id(),
-		&opts,
-	)
-
-	if err != nil {
-
-		if swerr, ok := err.(client.GenericSwaggerError); ok {
-
-			json_response := string(swerr.Body()[:])
-			response, jerr := structure.ExpandJsonFromString(json_response)
-
-			if jerr == nil {
-				statusCode := int(response["status"].(float64))
-				log.Printf("Error updating %s: %d\n", connection.AwsConnectionName, statusCode)
-				log.Printf("  %s\n", response["code"])
-				log.Printf("  %s\n", response["message"])
-			}
-		}
-
-		return fmt.Errorf("Error while updating %s: err=%s", connection.AwsConnectionName, err)
-	}
-
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while updating %s: code=%v", connection.AwsConnectionName, resp.StatusCode)
+	if err := connection.UpdateConnection(connection.AwsConnectionName, d, m, c); err != nil {
+		return err
 	}
 
-	if err := connection.WaitForConnection(connection.AwsConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.AwsConnectionName, err)
-	}
-
-	d.Partial(false)
-
 	return resourceAWSConnectionRead(d, m)
 }
 
@@ -101,6 +101,11 @@ resource "pureport_aws_connection" "basic" {
   aws_region = "${data.pureport_cloud_regions.main.regions.0.identifier}"
   aws_account_id = "${data.aws_caller_identity.current.account_id}"
 
+  customer_networks {
+    name = "A"
+    address = "192.168.0.0/16"
+  }
+
   tags = {
     Environment = "tf-test"
     Owner       = "scott-pilgram"
@@ -194,6 +199,21 @@ resource "pureport_aws_connection" "cloudServices" {
 }
 `
 
+const testAccResourceAWSConnectionConfig_publicNoCloudServices = testAccResourceAWSConnectionConfig_common + `
+resource "pureport_aws_connection" "publicNoCloudServices" {
+  name = "AwsDirectConnectPublicNoCloudServicesTest"
+  speed = "100"
+
+  location_href = "${data.pureport_locations.main.locations.0.href}"
+  network_href = "${data.pureport_networks.main.networks.0.href}"
+
+  peering_type = "PUBLIC"
+
+  aws_region = "${data.pureport_cloud_regions.main.regions.0.identifier}"
+  aws_account_id = "${data.aws_caller_identity.current.account_id}"
+}
+`
+
 const testAccResourceAWSConnectionConfig_nat_mapping = testAccResourceAWSConnectionConfig_common + `
 resource "pureport_aws_connection" "nat_mapping" {
   name = "AwsDirectConnectNatMappingTest"
@@ -243,15 +263,21 @@ func TestResourceAWSConnection_basic(t *testing.T) {
 					testAccCheckResourceAWSConnection(resourceName, &instance),
 
 					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Id),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "active_at"),
 					resource.TestCheckResourceAttr(resourceName, "name", "AwsDirectConnectTest"),
 					resource.TestCheckResourceAttr(resourceName, "description", ""),
 					resource.TestCheckResourceAttr(resourceName, "speed", "50"),
 					resource.TestCheckResourceAttr(resourceName, "high_availability", "true"),
 					resource.TestCheckResourceAttr(resourceName, "location_href", "/locations/us-sea"),
 					resource.TestMatchResourceAttr(resourceName, "network_href", regexp.MustCompile("/networks/network-.{16}")),
+					resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
+					resource.TestCheckResourceAttr(resourceName, "state", "ACTIVE"),
 
 					resource.TestCheckResourceAttr(resourceName, "gateways.#", "2"),
 
+					resource.TestCheckResourceAttrSet(resourceName, "gateways.0.id"),
+					resource.TestMatchResourceAttr(resourceName, "gateways.0.href", regexp.MustCompile("/gateways/.+")),
 					resource.TestCheckResourceAttr(resourceName, "gateways.0.availability_domain", "PRIMARY"),
 					resource.TestCheckResourceAttr(resourceName, "gateways.0.name", "AWS_DIRECT_CONNECT"),
 					resource.TestCheckResourceAttr(resourceName, "gateways.0.description", ""),
@@ -261,6 +287,8 @@ func TestResourceAWSConnection_basic(t *testing.T) {
 					resource.TestMatchResourceAttr(resourceName, "gateways.0.pureport_ip", regexp.MustCompile("169.254.[0-9]{1,3}.[0-9]{1,3}/30")),
 					resource.TestCheckResourceAttrSet(resourceName, "gateways.0.bgp_password"),
 					resource.TestMatchResourceAttr(resourceName, "gateways.0.peering_subnet", regexp.MustCompile("169.254.[0-9]{1,3}.[0-9]{1,3}")),
+					resource.TestCheckResourceAttr(resourceName, "gateways.0.state", "ACTIVE"),
+					resource.TestCheckResourceAttrSet(resourceName, "gateways.0.bgp_state"),
 					resource.TestCheckResourceAttr(resourceName, "gateways.0.public_nat_ip", ""),
 					resource.TestCheckResourceAttrSet(resourceName, "gateways.0.vlan"),
 					resource.TestCheckResourceAttrSet(resourceName, "gateways.0.remote_id"),
@@ -295,6 +323,7 @@ func TestResourceAWSConnection_basic(t *testing.T) {
 
 					resource.TestCheckResourceAttr(resourceName, "speed", "50"),
 					resource.TestCheckResourceAttr(resourceName, "high_availability", "true"),
+					resource.TestCheckResourceAttr(resourceName, "customer_networks.#", "1"),
 
 					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "scott-pilgram"),
 				),
@@ -371,6 +400,7 @@ func TestResourceAWSConnection_cloudServices(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "high_availability", "true"),
 					resource.TestCheckResourceAttr(resourceName, "location_href", "/locations/us-sea"),
 					resource.TestMatchResourceAttr(resourceName, "network_href", regexp.MustCompile("/networks/network-.{16}")),
+					resource.TestMatchResourceAttr(resourceName, "account_href", regexp.MustCompile("/accounts/ac-.{16}")),
 
 					resource.TestCheckResourceAttr(resourceName, "nat_config.0.enabled", "false"),
 					resource.TestCheckResourceAttr(resourceName, "nat_config.0.blocks.#", "0"),
@@ -408,6 +438,21 @@ func TestResourceAWSConnection_cloudServices(t *testing.T) {
 	})
 }
 
+func TestResourceAWSConnection_publicPeeringRequiresCloudServices(t *testing.T) {
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceAWSConnectionConfig_publicNoCloudServices,
+				ExpectError: regexp.MustCompile("requires at least one cloud service"),
+			},
+		},
+	})
+}
+
 func TestResourceAWSConnection_nat_mappings(t *testing.T) {
 
 	resourceName := "pureport_aws_connection.nat_mapping"
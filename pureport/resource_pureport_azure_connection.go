@@ -5,11 +5,10 @@ import (
 	"log"
 	"net/url"
 	"path/filepath"
-	"time"
 
 	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/structure"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/pureport/pureport-sdk-go/pureport/client"
 	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
@@ -25,12 +24,6 @@ func resourceAzureConnection() *schema.Resource {
 			Required: true,
 			ForceNew: true,
 		},
-		"speed": {
-			Type:         schema.TypeInt,
-			Required:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.IntInSlice([]int{50, 100, 200, 300, 400, 500, 1000, 10000}),
-		},
 		"peering_type": {
 			Type:         schema.TypeString,
 			Description:  "The peering type to use for this connection: [PUBLIC, PRIVATE]",
@@ -61,11 +54,24 @@ func resourceAzureConnection() *schema.Resource {
 		Update: resourceAzureConnectionUpdate,
 		Delete: resourceAzureConnectionDelete,
 
+		CustomizeDiff: customdiff.All(
+			connection.ValidateHighAvailabilityDowngrade,
+			connection.ForceNewSpeed("AZURE_EXPRESS_ROUTE"),
+			connection.ValidateSpeed("AZURE_EXPRESS_ROUTE"),
+			connection.ValidateBillingTerm("AZURE_EXPRESS_ROUTE"),
+			connection.EstimateCost("AZURE_EXPRESS_ROUTE"),
+		),
+
+		Importer: &schema.ResourceImporter{
+			State: connection.ImportConnection(connection.AzureConnectionName),
+		},
+
 		Schema: connection_schema,
 
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(6 * time.Minute),
-			Delete: schema.DefaultTimeout(6 * time.Minute),
+			Create: schema.DefaultTimeout(connection.DefaultTimeout),
+			Update: schema.DefaultTimeout(connection.DefaultTimeout),
+			Delete: schema.DefaultTimeout(connection.DefaultTimeout),
 		},
 	}
 }
@@ -73,7 +79,7 @@ func resourceAzureConnection() *schema.Resource {
 func expandAzureConnection(d *schema.ResourceData) client.AzureExpressRouteConnection {
 
 	// Generic Connection values
-	speed := d.Get("speed").(int)
+	speed := connection.GetSpeed(d)
 
 	// Azure specific values
 	serviceKey := d.Get("service_key").(string)
@@ -117,6 +123,16 @@ func expandAzureConnection(d *schema.ResourceData) client.AzureExpressRouteConne
 
 func resourceAzureConnectionCreate(d *schema.ResourceData, m interface{}) error {
 
+	if err := connection.ProvisionConnection(connection.AzureConnectionName, d, m, resourceAzureConnectionAdd); err != nil {
+		return err
+	}
+
+	return resourceAzureConnectionRead(d, m)
+}
+
+// resourceAzureConnectionAdd requests the new connection from the API
+func resourceAzureConnectionAdd(d *schema.ResourceData, m interface{}) error {
+
 	c := expandAzureConnection(d)
 
 	config := m.(*configuration.Config)
@@ -134,27 +150,10 @@ func resourceAzureConnectionCreate(d *schema.ResourceData, m interface{}) error
 	)
 
 	if err != nil {
-
-		http_err := err
-		json_response := string(err.(client.GenericSwaggerError).Body()[:])
-		response, err := structure.ExpandJsonFromString(json_response)
-		if err != nil {
-			log.Printf("Error Creating new %s: %v", connection.AzureConnectionName, err)
-
-		} else {
-			statusCode := int(response["status"].(float64))
-
-			log.Printf("Error Creating new %s: %d\n", connection.AzureConnectionName, statusCode)
-			log.Printf("  %s\n", response["code"])
-			log.Printf("  %s\n", response["message"])
-		}
-
-		d.SetId("")
-		return fmt.Errorf("Error while creating %s: err=%s", connection.AzureConnectionName, http_err)
+		return connection.CreateError(connection.AzureConnectionName, resp, err)
 	}
 
 	if resp.StatusCode >= 300 {
-		d.SetId("")
 		return fmt.Errorf("Error while creating %s: code=%v", connection.AzureConnectionName, resp.StatusCode)
 	}
 
@@ -172,70 +171,29 @@ func resourceAzureConnectionCreate(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Error when decoding Connection ID")
 	}
 
-	if err := connection.WaitForConnection(connection.AzureConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.AzureConnectionName, err)
-	}
-
-	return resourceAzureConnectionRead(d, m)
+	return nil
 }
 
 func resourceAzureConnectionRead(d *schema.ResourceData, m interface{}) error {
 
-	config := m.(*configuration.Config)
-	connectionId := d.Id()
-	ctx := config.Session.GetSessionContext()
-
-	c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			log.Printf("Error Response while reading %s: code=%v", connection.AzureConnectionName, resp.StatusCode)
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("Error reading data for %s: %s", connection.AzureConnectionName, err)
+	c, err := connection.ReadConnection(connection.AzureConnectionName, d, m)
+	if err != nil || c == nil {
+		return err
 	}
 
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while reading %s: code=%v", connection.AzureConnectionName, resp.StatusCode)
+	conn, ok := c.(client.AzureExpressRouteConnection)
+	if !ok {
+		return fmt.Errorf("Error reading %s %s: connection is not a %s", connection.AzureConnectionName, d.Id(), connection.AzureConnectionName)
 	}
 
-	conn := c.(client.AzureExpressRouteConnection)
-	d.Set("description", conn.Description)
-	d.Set("high_availability", conn.HighAvailability)
-	d.Set("href", conn.Href)
-	d.Set("name", conn.Name)
-	d.Set("peering_type", conn.Peering.Type_)
-	d.Set("service_key", conn.ServiceKey)
-	d.Set("speed", conn.Speed)
-	d.Set("state", conn.State)
-
-	if err := d.Set("customer_networks", connection.FlattenCustomerNetworks(conn.CustomerNetworks)); err != nil {
-		return fmt.Errorf("Error setting customer networks for %s %s: %s", connection.AzureConnectionName, d.Id(), err)
+	if err := connection.FlattenConnection(connection.AzureConnectionName, d, conn); err != nil {
+		return err
 	}
 
-	// Add Gateway information
-	var gateways []map[string]interface{}
-	if g := conn.PrimaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenStandardGateway(g))
-	}
-	if g := conn.SecondaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenStandardGateway(g))
-	}
-	if err := d.Set("gateways", gateways); err != nil {
-		return fmt.Errorf("Error setting gateway information for %s %s: %s", connection.AzureConnectionName, d.Id(), err)
-	}
-
-	// NAT Configuration
-	if err := d.Set("nat_config", connection.FlattenNatConfig(conn.Nat)); err != nil {
-		return fmt.Errorf("Error setting NAT Configuration for %s %s: %s", connection.AzureConnectionName, d.Id(), err)
-	}
+	connection.ReadConnectionAccount(connection.AzureConnectionName, d, m)
 
-	if err := d.Set("location_href", conn.Location.Href); err != nil {
-		return fmt.Errorf("Error setting location for %s %s: %s", connection.AzureConnectionName, d.Id(), err)
-	}
-	if err := d.Set("network_href", conn.Network.Href); err != nil {
-		return fmt.Errorf("Error setting network for %s %s: %s", connection.AzureConnectionName, d.Id(), err)
-	}
+	d.Set("peering_type", conn.Peering.Type_)
+	d.Set("service_key", conn.ServiceKey)
 
 	if err := d.Set("tags", conn.Tags); err != nil {
 		return fmt.Errorf("Error setting tags for %s %s: %s", connection.AzureConnectionName, d.Id(), err)
@@ -248,81 +206,16 @@ func resourceAzureConnectionUpdate(d *schema.ResourceData, m interface{}) error
 
 	c := expandAzureConnection(d)
 
-	d.Partial(true)
-
-	config := m.(*configuration.Config)
-	ctx := config.Session.GetSessionContext()
-
-	if d.HasChange("name") {
-		c.Name = d.Get("name").(string)
-		d.SetPartial("name")
-	}
-
-	if d.HasChange("description") {
-		c.Description = d.Get("description").(string)
-		d.SetPartial("description")
-	}
-
-	if d.HasChange("speed") {
-		c.Speed = int32(d.Get("speed").(int))
-		d.SetPartial("speed")
-	}
-
-	if d.HasChange("customer_networks") {
-		c.CustomerNetworks = connection.ExpandCustomerNetworks(d)
-	}
-
-	if d.HasChange("nat_config") {
-		c.Nat = connection.ExpandNATConfiguration(d)
-	}
-
-	if d.HasChange("billing_term") {
-		c.BillingTerm = d.Get("billing_term").(string)
-	}
-
+	// Removing all tags has to send an empty map, not omit the tags
 	if d.HasChange("tags") {
 		_, nraw := d.GetChange("tags")
 		c.Tags = tags.FilterTags(nraw.(map[string]interface{}))
 	}
 
-	opts := client.UpdateConnectionOpts{
-		Body: optional.NewInterface(c),
+	if err := connection.UpdateConnection(connection.AzureConnectionName, d, m, c); err != nil {
+		return err
 	}
 
-	_, resp, err := config.Session.Client.ConnectionsApi.UpdateConnection(
-		ctx,
-		d.Id(),
-		&opts,
-	)
-
-	if err != nil {
-
-		if swerr, ok := err.(client.GenericSwaggerError); ok {
-
-			json_response := string(swerr.Body()[:])
-			response, jerr := structure.ExpandJsonFromString(json_response)
-
-			if jerr == nil {
-				statusCode := int(response["status"].(float64))
-				log.Printf("Error updating %s: %d\n", connection.AzureConnectionName, statusCode)
-				log.Printf("  %s\n", response["code"])
-				log.Printf("  %s\n", response["message"])
-			}
-		}
-
-		return fmt.Errorf("Error while updating %s: err=%s", connection.AzureConnectionName, err)
-	}
-
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while updating %s: code=%v", connection.AzureConnectionName, resp.StatusCode)
-	}
-
-	if err := connection.WaitForConnection(connection.AzureConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.AzureConnectionName, err)
-	}
-
-	d.Partial(false)
-
 	return resourceAzureConnectionRead(d, m)
 }
 
@@ -96,6 +96,34 @@ resource "pureport_azure_connection" "main" {
 `
 }
 
+func testAccResourceAzureConnectionConfig_update() string {
+
+	return testAccResourceAzureConnectionConfig_common() + `
+resource "pureport_azure_connection" "main" {
+  name = "AzureExpressRouteTest Updated"
+  description = "Updated description"
+  speed = "100"
+  high_availability = true
+
+  location_href = "${data.pureport_locations.main.locations.0.href}"
+  network_href = "${data.pureport_networks.main.networks.0.href}"
+
+  service_key = "${data.azurerm_express_route_circuit.main.service_key}"
+
+  customer_networks {
+    name = "A"
+    address = "192.168.0.0/16"
+  }
+
+  tags = {
+    Environment = "tf-test"
+    Owner       = "scott-pilgram"
+    sweep       = "TRUE"
+  }
+}
+`
+}
+
 func TestResourceAzureConnection_basic(t *testing.T) {
 
 	resourceName := "pureport_azure_connection.main"
@@ -152,6 +180,17 @@ func TestResourceAzureConnection_basic(t *testing.T) {
 					),
 				),
 			},
+			{
+				Config: testAccResourceAzureConnectionConfig_update(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Id),
+					resource.TestCheckResourceAttr(resourceName, "name", "AzureExpressRouteTest Updated"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Updated description"),
+					resource.TestCheckResourceAttr(resourceName, "speed", "100"),
+					resource.TestCheckResourceAttr(resourceName, "customer_networks.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "scott-pilgram"),
+				),
+			},
 		},
 	})
 }
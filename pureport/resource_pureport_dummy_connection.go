@@ -0,0 +1,220 @@
+package pureport
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"path/filepath"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
+)
+
+func resourceDummyConnection() *schema.Resource {
+
+	connection_schema := map[string]*schema.Schema{
+		"peering_type": {
+			Type:         schema.TypeString,
+			Description:  "The peering type to use for this connection: [PUBLIC, PRIVATE]",
+			Default:      "PRIVATE",
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"private", "public"}, true),
+		},
+		"gateways": {
+			Computed: true,
+			Type:     schema.TypeList,
+			MinItems: 1,
+			MaxItems: 2,
+			Elem: &schema.Resource{
+				Schema: connection.StandardGatewaySchema,
+			},
+		},
+		"simulated_provisioning_delay": {
+			Type:         schema.TypeInt,
+			Description:  "The number of seconds to report the connection as provisioning after it becomes active, to simulate slow provisioning.",
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"simulated_failure": {
+			Type:        schema.TypeBool,
+			Description: "Report the connection as failed to provision with a retryable error once it becomes active.",
+			Optional:    true,
+			Default:     false,
+		},
+	}
+
+	// Add the base items
+	for k, v := range connection.GetBaseResourceConnectionSchema() {
+		connection_schema[k] = v
+	}
+
+	// Dummy Connections don't support tags
+	delete(connection_schema, "tags")
+
+	return &schema.Resource{
+		Create: resourceDummyConnectionCreate,
+		Read:   resourceDummyConnectionRead,
+		Update: resourceDummyConnectionUpdate,
+		Delete: resourceDummyConnectionDelete,
+
+		CustomizeDiff: customdiff.All(
+			connection.ValidateHighAvailabilityDowngrade,
+			connection.ForceNewSpeed("DUMMY"),
+			connection.ValidateSpeed("DUMMY"),
+			connection.ValidateBillingTerm("DUMMY"),
+			connection.EstimateCost("DUMMY"),
+		),
+
+		Importer: &schema.ResourceImporter{
+			State: connection.ImportConnection(connection.DummyConnectionName),
+		},
+
+		Schema: connection_schema,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(connection.DefaultTimeout),
+			Update: schema.DefaultTimeout(connection.DefaultTimeout),
+			Delete: schema.DefaultTimeout(connection.DefaultTimeout),
+		},
+	}
+}
+
+func expandDummyConnection(d *schema.ResourceData) client.DummyConnection {
+
+	// Generic Connection values
+	speed := connection.GetSpeed(d)
+
+	// Create the body of the request
+	c := client.DummyConnection{
+		Type_: "DUMMY",
+		Name:  d.Get("name").(string),
+		Speed: int32(speed),
+		Location: &client.Link{
+			Href: d.Get("location_href").(string),
+		},
+		Network: &client.Link{
+			Href: d.Get("network_href").(string),
+		},
+		BillingTerm: d.Get("billing_term").(string),
+	}
+
+	// Generic Optionals
+	c.CustomerNetworks = connection.ExpandCustomerNetworks(d)
+	c.Nat = connection.ExpandNATConfiguration(d)
+	c.Peering = connection.ExpandPeeringType(d)
+
+	if description, ok := d.GetOk("description"); ok {
+		c.Description = description.(string)
+	}
+
+	if highAvailability, ok := d.GetOk("high_availability"); ok {
+		c.HighAvailability = highAvailability.(bool)
+	}
+
+	if customerASN, ok := d.GetOk("customer_asn"); ok {
+		c.CustomerASN = int64(customerASN.(int))
+	}
+
+	return c
+}
+
+func resourceDummyConnectionCreate(d *schema.ResourceData, m interface{}) error {
+
+	if err := connection.ProvisionConnection(connection.DummyConnectionName, d, m, resourceDummyConnectionAdd); err != nil {
+		return err
+	}
+
+	return resourceDummyConnectionRead(d, m)
+}
+
+// resourceDummyConnectionAdd requests the new connection from the API
+func resourceDummyConnectionAdd(d *schema.ResourceData, m interface{}) error {
+
+	c := expandDummyConnection(d)
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	opts := client.AddConnectionOpts{
+		Body: optional.NewInterface(c),
+	}
+
+	_, resp, err := config.Session.Client.ConnectionsApi.AddConnection(
+		ctx,
+		filepath.Base(c.Network.Href),
+		&opts,
+	)
+
+	if err != nil {
+		return connection.CreateError(connection.DummyConnectionName, resp, err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Error while creating %s: code=%v", connection.DummyConnectionName, resp.StatusCode)
+	}
+
+	loc := resp.Header.Get("location")
+	u, err := url.Parse(loc)
+	if err != nil {
+		return fmt.Errorf("Error when decoding Connection ID")
+	}
+
+	id := filepath.Base(u.Path)
+	d.SetId(id)
+
+	if id == "" {
+		log.Printf("Error when decoding location header")
+		return fmt.Errorf("Error when decoding Connection ID")
+	}
+
+	return nil
+}
+
+func resourceDummyConnectionRead(d *schema.ResourceData, m interface{}) error {
+
+	c, err := connection.ReadConnection(connection.DummyConnectionName, d, m)
+	if err != nil || c == nil {
+		return err
+	}
+
+	conn, ok := c.(client.DummyConnection)
+	if !ok {
+		return fmt.Errorf("Error reading %s %s: connection is not a %s", connection.DummyConnectionName, d.Id(), connection.DummyConnectionName)
+	}
+
+	if err := connection.FlattenConnection(connection.DummyConnectionName, d, conn); err != nil {
+		return err
+	}
+
+	connection.ReadConnectionAccount(connection.DummyConnectionName, d, m)
+
+	d.Set("customer_asn", conn.CustomerASN)
+
+	if conn.Peering != nil {
+		d.Set("peering_type", conn.Peering.Type_)
+	}
+
+	return nil
+}
+
+func resourceDummyConnectionUpdate(d *schema.ResourceData, m interface{}) error {
+
+	c := expandDummyConnection(d)
+
+	if err := connection.UpdateConnection(connection.DummyConnectionName, d, m, c); err != nil {
+		return err
+	}
+
+	return resourceDummyConnectionRead(d, m)
+}
+
+func resourceDummyConnectionDelete(d *schema.ResourceData, m interface{}) error {
+	return connection.DeleteConnection(connection.DummyConnectionName, d, m)
+}
@@ -0,0 +1,176 @@
+package pureport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pureport/pureport-sdk-go/pureport/client"
+	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+)
+
+const testAccResourceDummyConnectionConfig_common = `
+data "pureport_accounts" "main" {
+  filter {
+    name = "Name"
+    values = ["Terraform .*"]
+  }
+}
+
+data "pureport_locations" "main" {
+  filter {
+    name = "Name"
+    values = ["Sea.*"]
+  }
+}
+
+data "pureport_networks" "main" {
+  account_href = "${data.pureport_accounts.main.accounts.0.href}"
+  filter {
+    name = "Name"
+    values = ["Bansh.*"]
+  }
+}
+`
+
+const testAccResourceDummyConnectionConfig_basic = testAccResourceDummyConnectionConfig_common + `
+resource "pureport_dummy_connection" "main" {
+  name = "DummyTest"
+  description = "Dummy Terraform Test"
+  speed = "100"
+  high_availability = true
+
+  location_href = "${data.pureport_locations.main.locations.0.href}"
+  network_href = "${data.pureport_networks.main.networks.0.href}"
+}
+`
+
+const testAccResourceDummyConnectionConfig_updated = testAccResourceDummyConnectionConfig_common + `
+resource "pureport_dummy_connection" "main" {
+  name = "DummyTestUpdated"
+  description = "Dummy Terraform Test Updated"
+  speed = "200"
+  high_availability = true
+
+  location_href = "${data.pureport_locations.main.locations.0.href}"
+  network_href = "${data.pureport_networks.main.networks.0.href}"
+}
+`
+
+func TestResourceDummyConnection_basic(t *testing.T) {
+
+	resourceName := "pureport_dummy_connection.main"
+	var instance client.DummyConnection
+	var updated client.DummyConnection
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDummyConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDummyConnectionConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceDummyConnection(resourceName, &instance),
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Id),
+					resource.TestCheckResourceAttr(resourceName, "name", "DummyTest"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Dummy Terraform Test"),
+					resource.TestCheckResourceAttr(resourceName, "speed", "100"),
+					resource.TestCheckResourceAttr(resourceName, "high_availability", "true"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.0.availability_domain", "PRIMARY"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.1.availability_domain", "SECONDARY"),
+				),
+			},
+			{
+				Config: testAccResourceDummyConnectionConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceDummyConnection(resourceName, &updated),
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Id),
+					resource.TestCheckResourceAttr(resourceName, "name", "DummyTestUpdated"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Dummy Terraform Test Updated"),
+					resource.TestCheckResourceAttr(resourceName, "speed", "200"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"adopt_existing",
+					"allow_ha_downgrade",
+					"deletion_protection",
+					"estimated_monthly_cost",
+					"provisioning_retries",
+					"simulated_failure",
+					"simulated_provisioning_delay",
+					"wait_for_bgp",
+					"wait_for_provisioning",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckResourceDummyConnection(name string, instance *client.DummyConnection) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		config, ok := testAccProvider.Meta().(*configuration.Config)
+		if !ok {
+			return fmt.Errorf("Error getting Pureport client")
+		}
+
+		// Find the state object
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Can't find Dummy Connection resource: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		id := rs.Primary.ID
+
+		ctx := config.Session.GetSessionContext()
+		found, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, id)
+
+		if err != nil {
+			return fmt.Errorf("receive error when requesting Dummy Connection %s", id)
+		}
+
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("Error getting Dummy Connection ID %s: %s", id, err)
+		}
+
+		*instance = found.(client.DummyConnection)
+
+		return nil
+	}
+}
+
+func testAccCheckDummyConnectionDestroy(s *terraform.State) error {
+
+	config, ok := testAccProvider.Meta().(*configuration.Config)
+	if !ok {
+		return fmt.Errorf("Error getting Pureport client")
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "pureport_dummy_connection" {
+			continue
+		}
+
+		id := rs.Primary.ID
+
+		ctx := config.Session.GetSessionContext()
+		_, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, id)
+
+		if err != nil && resp.StatusCode != 404 {
+			return fmt.Errorf("should not get error for Dummy Connection with ID %s after delete: %s", id, err)
+		}
+	}
+
+	return nil
+}
@@ -5,12 +5,11 @@ import (
 	"log"
 	"net/url"
 	"path/filepath"
-	"time"
+	"strings"
 
 	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/structure"
-	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/pureport/pureport-sdk-go/pureport/client"
 	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
 	"github.com/pureport/terraform-provider-pureport/pureport/connection"
@@ -25,17 +24,26 @@ func resourceGoogleCloudConnection() *schema.Resource {
 			Required: true,
 			ForceNew: true,
 		},
-		"speed": {
-			Type:         schema.TypeInt,
-			Required:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.IntInSlice([]int{50, 100, 200, 300, 400, 500, 1000, 10000}),
-		},
 		"secondary_pairing_key": {
 			Type:     schema.TypeString,
 			Optional: true,
 			ForceNew: true,
 		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "The Google Cloud region of the Interconnect Attachments, from the pairing key.",
+			Computed:    true,
+		},
+		"primary_edge_availability_domain": {
+			Type:        schema.TypeString,
+			Description: "The edge availability domain of the primary Interconnect Attachment, from the pairing key.",
+			Computed:    true,
+		},
+		"secondary_edge_availability_domain": {
+			Type:        schema.TypeString,
+			Description: "The edge availability domain of the secondary Interconnect Attachment, from the pairing key.",
+			Computed:    true,
+		},
 		"gateways": {
 			Computed: true,
 			Type:     schema.TypeList,
@@ -58,11 +66,24 @@ func resourceGoogleCloudConnection() *schema.Resource {
 		Update: resourceGoogleCloudConnectionUpdate,
 		Delete: resourceGoogleCloudConnectionDelete,
 
+		CustomizeDiff: customdiff.All(
+			connection.ValidateHighAvailabilityDowngrade,
+			connection.ForceNewSpeed("GOOGLE_CLOUD_INTERCONNECT"),
+			connection.ValidateSpeed("GOOGLE_CLOUD_INTERCONNECT"),
+			connection.ValidateBillingTerm("GOOGLE_CLOUD_INTERCONNECT"),
+			connection.EstimateCost("GOOGLE_CLOUD_INTERCONNECT"),
+		),
+
+		Importer: &schema.ResourceImporter{
+			State: connection.ImportConnection(connection.GoogleConnectionName),
+		},
+
 		Schema: connection_schema,
 
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(6 * time.Minute),
-			Delete: schema.DefaultTimeout(6 * time.Minute),
+			Create: schema.DefaultTimeout(connection.DefaultTimeout),
+			Update: schema.DefaultTimeout(connection.DefaultTimeout),
+			Delete: schema.DefaultTimeout(connection.DefaultTimeout),
 		},
 	}
 }
@@ -70,7 +91,7 @@ func resourceGoogleCloudConnection() *schema.Resource {
 func expandGoogleCloudConnection(d *schema.ResourceData) client.GoogleCloudInterconnectConnection {
 
 	// Generic Connection values
-	speed := d.Get("speed").(int)
+	speed := connection.GetSpeed(d)
 
 	// Google specific values
 	primaryPairingKey := d.Get("primary_pairing_key").(string)
@@ -116,6 +137,16 @@ func expandGoogleCloudConnection(d *schema.ResourceData) client.GoogleCloudInter
 
 func resourceGoogleCloudConnectionCreate(d *schema.ResourceData, m interface{}) error {
 
+	if err := connection.ProvisionConnection(connection.GoogleConnectionName, d, m, resourceGoogleCloudConnectionAdd); err != nil {
+		return err
+	}
+
+	return resourceGoogleCloudConnectionRead(d, m)
+}
+
+// resourceGoogleCloudConnectionAdd requests the new connection from the API
+func resourceGoogleCloudConnectionAdd(d *schema.ResourceData, m interface{}) error {
+
 	c := expandGoogleCloudConnection(d)
 
 	config := m.(*configuration.Config)
@@ -132,34 +163,10 @@ func resourceGoogleCloudConnectionCreate(d *schema.ResourceData, m interface{})
 	)
 
 	if err != nil {
-
-		http_err := err
-
-		switch e := err.(type) {
-		case client.GenericSwaggerError:
-			json_response := string(e.Body()[:])
-			response, err := structure.ExpandJsonFromString(json_response)
-
-			if err != nil {
-				log.Printf("Error creating new %s: %v", connection.GoogleConnectionName, err)
-			} else {
-				statusCode := int(response["status"].(float64))
-				log.Printf("Error creating new %s: %d\n", connection.GoogleConnectionName, statusCode)
-				log.Printf("  %s\n", response["code"])
-				log.Printf("  %s\n", response["message"])
-			}
-		case *url.Error:
-			log.Printf("Error creating new %s: %s", connection.GoogleConnectionName, e.Error())
-		default:
-			log.Printf("Error creating new %s: %v", connection.GoogleConnectionName, e)
-		}
-
-		d.SetId("")
-		return fmt.Errorf("Error while creating %s: err=%s", connection.GoogleConnectionName, http_err)
+		return connection.CreateError(connection.GoogleConnectionName, resp, err)
 	}
 
 	if resp.StatusCode >= 300 {
-		d.SetId("")
 		return fmt.Errorf("Error while creating %s: code=%v", connection.GoogleConnectionName, resp.StatusCode)
 	}
 
@@ -177,70 +184,36 @@ func resourceGoogleCloudConnectionCreate(d *schema.ResourceData, m interface{})
 		return fmt.Errorf("Error when decoding Connection ID")
 	}
 
-	if err := connection.WaitForConnection(connection.GoogleConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.GoogleConnectionName, err)
-	}
-
-	return resourceGoogleCloudConnectionRead(d, m)
+	return nil
 }
 
 func resourceGoogleCloudConnectionRead(d *schema.ResourceData, m interface{}) error {
 
-	config := m.(*configuration.Config)
-	connectionId := d.Id()
-	ctx := config.Session.GetSessionContext()
+	c, err := connection.ReadConnection(connection.GoogleConnectionName, d, m)
+	if err != nil || c == nil {
+		return err
+	}
 
-	c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			log.Printf("Error Response while reading %s: code=%v", connection.GoogleConnectionName, resp.StatusCode)
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("Error reading data for %s: %s", connection.GoogleConnectionName, err)
+	conn, ok := c.(client.GoogleCloudInterconnectConnection)
+	if !ok {
+		return fmt.Errorf("Error reading %s %s: connection is not a %s", connection.GoogleConnectionName, d.Id(), connection.GoogleConnectionName)
 	}
 
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while reading %s: code=%v", connection.GoogleConnectionName, resp.StatusCode)
+	if err := connection.FlattenConnection(connection.GoogleConnectionName, d, conn); err != nil {
+		return err
 	}
 
-	conn := c.(client.GoogleCloudInterconnectConnection)
-	d.Set("description", conn.Description)
-	d.Set("high_availability", conn.HighAvailability)
-	d.Set("href", conn.Href)
-	d.Set("name", conn.Name)
+	connection.ReadConnectionAccount(connection.GoogleConnectionName, d, m)
+
 	d.Set("primary_pairing_key", conn.PrimaryPairingKey)
 	d.Set("secondary_pairing_key", conn.SecondaryPairingKey)
-	d.Set("speed", conn.Speed)
-	d.Set("state", conn.State)
-
-	if err := d.Set("customer_networks", connection.FlattenCustomerNetworks(conn.CustomerNetworks)); err != nil {
-		return fmt.Errorf("Error setting customer networks for %s %s: %s", connection.GoogleConnectionName, d.Id(), err)
-	}
-
-	// Add Gateway information
-	var gateways []map[string]interface{}
-	if g := conn.PrimaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenStandardGateway(g))
-	}
-	if g := conn.SecondaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenStandardGateway(g))
-	}
-	if err := d.Set("gateways", gateways); err != nil {
-		return fmt.Errorf("Error setting gateway information for %s %s: %s", connection.GoogleConnectionName, d.Id(), err)
-	}
 
-	// NAT Configuration
-	if err := d.Set("nat_config", connection.FlattenNatConfig(conn.Nat)); err != nil {
-		return fmt.Errorf("Error setting NAT Configuration for %s %s: %s", connection.GoogleConnectionName, d.Id(), err)
-	}
+	region, domain := parsePairingKey(conn.PrimaryPairingKey)
+	d.Set("region", region)
+	d.Set("primary_edge_availability_domain", domain)
 
-	if err := d.Set("location_href", conn.Location.Href); err != nil {
-		return fmt.Errorf("Error setting location for %s %s: %s", connection.GoogleConnectionName, d.Id(), err)
-	}
-	if err := d.Set("network_href", conn.Network.Href); err != nil {
-		return fmt.Errorf("Error setting network for %s %s: %s", connection.GoogleConnectionName, d.Id(), err)
-	}
+	_, domain = parsePairingKey(conn.SecondaryPairingKey)
+	d.Set("secondary_edge_availability_domain", domain)
 
 	if err := d.Set("tags", conn.Tags); err != nil {
 		return fmt.Errorf("Error setting tags for %s %s: %s", connection.GoogleConnectionName, d.Id(), err)
@@ -253,84 +226,33 @@ func resourceGoogleCloudConnectionUpdate(d *schema.ResourceData, m interface{})
 
 	c := expandGoogleCloudConnection(d)
 
-	d.Partial(true)
-
-	config := m.(*configuration.Config)
-	ctx := config.Session.GetSessionContext()
-
-	if d.HasChange("name") {
-		c.Name = d.Get("name").(string)
-		d.SetPartial("name")
-	}
-
-	if d.HasChange("description") {
-		c.Description = d.Get("description").(string)
-		d.SetPartial("description")
-	}
-
-	if d.HasChange("speed") {
-		c.Speed = int32(d.Get("speed").(int))
-		d.SetPartial("speed")
-	}
-
-	if d.HasChange("customer_networks") {
-		c.CustomerNetworks = connection.ExpandCustomerNetworks(d)
-	}
-
-	if d.HasChange("nat_config") {
-		c.Nat = connection.ExpandNATConfiguration(d)
-	}
-
-	if d.HasChange("billing_term") {
-		c.BillingTerm = d.Get("billing_term").(string)
-	}
-
+	// Removing all tags has to send an empty map, not omit the tags
 	if d.HasChange("tags") {
 		_, nraw := d.GetChange("tags")
 		c.Tags = tags.FilterTags(nraw.(map[string]interface{}))
 	}
 
-	opts := client.UpdateConnectionOpts{
-		Body: optional.NewInterface(c),
-	}
-
-	_, resp, err := config.Session.Client.ConnectionsApi.UpdateConnection(
-		ctx,
-		d.Id(),
-		&opts,
-	)
-
-	if err != nil {
-
-		if swerr, ok := err.(client.GenericSwaggerError); ok {
-
-			json_response := string(swerr.Body()[:])
-			response, jerr := structure.ExpandJsonFromString(json_response)
-
-			if jerr == nil {
-				statusCode := int(response["status"].(float64))
-				log.Printf("Error updating %s: %d\n", connection.GoogleConnectionName, statusCode)
-				log.Printf("  %s\n", response["code"])
-				log.Printf("  %s\n", response["message"])
-			}
-		}
-
-		return fmt.Errorf("Error while updating %s: err=%s", connection.GoogleConnectionName, err)
-	}
-
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while updating %s: code=%v", connection.GoogleConnectionName, resp.StatusCode)
-	}
-
-	if err := connection.WaitForConnection(connection.GoogleConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.GoogleConnectionName, err)
+	if err := connection.UpdateConnection(connection.GoogleConnectionName, d, m, c); err != nil {
+		return err
 	}
 
-	d.Partial(false)
-
 	return resourceGoogleCloudConnectionRead(d, m)
 }
 
 func resourceGoogleCloudConnectionDelete(d *schema.ResourceData, m interface{}) error {
 	return connection.DeleteConnection(connection.GoogleConnectionName, d, m)
 }
+
+// parsePairingKey returns the region and edge availability domain encoded in a
+// Google Cloud pairing key, which has the format <key>/<region>/<domain>, e.g.
+// 7e51371e-72a3-40b5-b844-2e3efefaee59/us-central1/1. The edge availability
+// domain is returned as used by the Google provider, e.g. AVAILABILITY_DOMAIN_1.
+func parsePairingKey(key string) (region string, domain string) {
+
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 {
+		return "", ""
+	}
+
+	return parts[1], "AVAILABILITY_DOMAIN_" + parts[2]
+}
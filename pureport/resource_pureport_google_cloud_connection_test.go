@@ -59,7 +59,7 @@ data "pureport_networks" "main" {
 }
 `
 
-func testAccResourceGoogleCloudConnectionConfig_basic() string {
+func testAccResourceGoogleCloudConnectionConfig_google() string {
 
 	format := testAccResourceGoogleCloudConnectionConfig_common + `
 data "google_compute_network" "default" {
@@ -89,7 +89,18 @@ resource "google_compute_interconnect_attachment" "main" {
 
   count = 2
 }
+`
+
+	if testEnvironmentName == "Production" {
+		return fmt.Sprintf(format, "prod")
+	}
+
+	return fmt.Sprintf(format, "dev1")
+}
 
+func testAccResourceGoogleCloudConnectionConfig_basic() string {
+
+	return testAccResourceGoogleCloudConnectionConfig_google() + `
 resource "pureport_google_cloud_connection" "main" {
   name = "GoogleCloudTest"
   speed = "50"
@@ -106,12 +117,33 @@ resource "pureport_google_cloud_connection" "main" {
   }
 }
 `
+}
 
-	if testEnvironmentName == "Production" {
-		return fmt.Sprintf(format, "prod")
-	}
+func testAccResourceGoogleCloudConnectionConfig_update() string {
 
-	return fmt.Sprintf(format, "dev1")
+	return testAccResourceGoogleCloudConnectionConfig_google() + `
+resource "pureport_google_cloud_connection" "main" {
+  name = "GoogleCloudTest Updated"
+  description = "Updated description"
+  speed = "50"
+
+  location_href = "${data.pureport_locations.main.locations.0.href}"
+  network_href = "${data.pureport_networks.main.networks.0.href}"
+
+  primary_pairing_key = "${google_compute_interconnect_attachment.main.0.pairing_key}"
+
+  customer_networks {
+    name = "A"
+    address = "192.168.0.0/16"
+  }
+
+  tags = {
+    Environment = "tf-test"
+    Owner       = "scott-pilgram"
+    sweep       = "TRUE"
+  }
+}
+`
 }
 
 func TestResourceGoogleCloudConnection_basic(t *testing.T) {
@@ -135,8 +167,13 @@ func TestResourceGoogleCloudConnection_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "high_availability", "false"),
 					resource.TestCheckResourceAttrSet(resourceName, "primary_pairing_key"),
 					resource.TestCheckResourceAttr(resourceName, "secondary_pairing_key", ""),
+					resource.TestCheckResourceAttrSet(resourceName, "region"),
+					resource.TestCheckResourceAttr(resourceName, "primary_edge_availability_domain", "AVAILABILITY_DOMAIN_1"),
+					resource.TestCheckResourceAttr(resourceName, "secondary_edge_availability_domain", ""),
 
-					resource.TestCheckResourceAttr(resourceName, "gateways.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.1.id", ""),
+					resource.TestCheckResourceAttr(resourceName, "gateways.1.state", ""),
 					resource.TestCheckResourceAttr(resourceName, "gateways.0.availability_domain", "PRIMARY"),
 					resource.TestCheckResourceAttr(resourceName, "gateways.0.name", "GOOGLE_CLOUD_INTERCONNECT"),
 					resource.TestCheckResourceAttr(resourceName, "gateways.0.description", ""),
@@ -154,6 +191,17 @@ func TestResourceGoogleCloudConnection_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "ksk-google"),
 				),
 			},
+			{
+				Config: testAccResourceGoogleCloudConnectionConfig_update(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Id),
+					resource.TestCheckResourceAttr(resourceName, "name", "GoogleCloudTest Updated"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Updated description"),
+					resource.TestCheckResourceAttr(resourceName, "speed", "50"),
+					resource.TestCheckResourceAttr(resourceName, "customer_networks.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "scott-pilgram"),
+				),
+			},
 		},
 	})
 }
@@ -223,3 +271,25 @@ func testAccCheckGoogleCloudConnectionDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestParsePairingKey(t *testing.T) {
+
+	cases := []struct {
+		Key    string
+		Region string
+		Domain string
+	}{
+		{Key: "7e51371e-72a3-40b5-b844-2e3efefaee59/us-central1/2", Region: "us-central1", Domain: "AVAILABILITY_DOMAIN_2"},
+		{Key: "", Region: "", Domain: ""},
+		{Key: "invalid", Region: "", Domain: ""},
+	}
+
+	for _, c := range cases {
+
+		region, domain := parsePairingKey(c.Key)
+
+		if region != c.Region || domain != c.Domain {
+			t.Errorf("%q: expected (%q, %q), got (%q, %q)", c.Key, c.Region, c.Domain, region, domain)
+		}
+	}
+}
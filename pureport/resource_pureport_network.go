@@ -3,14 +3,16 @@ package pureport
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"path/filepath"
+	"time"
 
 	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/structure"
 	"github.com/pureport/pureport-sdk-go/pureport/client"
 	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
+	"github.com/pureport/terraform-provider-pureport/pureport/connection"
 	"github.com/pureport/terraform-provider-pureport/pureport/tags"
 )
 
@@ -21,14 +23,23 @@ func resourceNetwork() *schema.Resource {
 		Update: resourceNetworkUpdate,
 		Delete: resourceNetworkDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: defaultAccountHref,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"account_href": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Description: "The account of the network. Defaults to the account_href of the provider.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -66,36 +77,51 @@ func resourceNetworkCreate(d *schema.ResourceData, m interface{}) error {
 	config := m.(*configuration.Config)
 	ctx := config.Session.GetSessionContext()
 
+	if err := checkNetworkNameAvailable(network.Name, accountId, m); err != nil {
+		return err
+	}
+
 	opts := client.AddNetworkOpts{
 		Body: optional.NewInterface(network),
 	}
 
-	_, resp, err := config.Session.Client.NetworksApi.AddNetwork(
-		ctx,
-		accountId,
-		&opts,
-	)
+	var resp *http.Response
+	var err error
 
-	if err != nil {
+	for attempt := 0; ; attempt++ {
 
-		http_err := err
-		json_response := string(err.(client.GenericSwaggerError).Body()[:])
-		response, err := structure.ExpandJsonFromString(json_response)
-		if err != nil {
-			log.Printf("Error Creating new Network: %v", err)
-		} else {
-			statusCode := int(response["status"].(float64))
-			log.Printf("Error Creating new Network: %d\n", statusCode)
-			log.Printf("  %s\n", response["code"])
-			log.Printf("  %s\n", response["message"])
+		_, resp, err = config.Session.Client.NetworksApi.AddNetwork(
+			ctx,
+			accountId,
+			&opts,
+		)
+
+		if err == nil || !connection.IsTransientResponse(resp) || attempt >= connection.CreateRetries {
+			break
 		}
 
-		d.SetId("")
-		return fmt.Errorf("Error while creating Network: err=%s", http_err)
+		// The failed request may still have created the Network
+		existing, ferr := findNetworkByName(network.Name, accountId, m)
+		if ferr != nil {
+			log.Printf("[Info] Unable to check whether the Network was created: %s", ferr)
+			break
+		}
+
+		if existing != nil {
+			log.Printf("[Info] Network %s was created by a request that failed: %s", existing.Id, err)
+			d.SetId(existing.Id)
+			return resourceNetworkRead(d, m)
+		}
+
+		log.Printf("[Info] Failed to create Network with a transient error, retrying (%d/%d): %s", attempt+1, connection.CreateRetries, err)
+		time.Sleep(time.Duration(attempt+1) * connection.CreateRetryDelay)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error while creating Network: err=%s", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
-		d.SetId("")
 		return fmt.Errorf("Error while creating network: code=%v", resp.StatusCode)
 	}
 
@@ -116,6 +142,47 @@ func resourceNetworkCreate(d *schema.ResourceData, m interface{}) error {
 	return resourceNetworkRead(d, m)
 }
 
+// findNetworkByName returns the network in the account with the name, or nil
+// when there isn't one.
+func findNetworkByName(name string, accountId string, m interface{}) (*client.Network, error) {
+
+	config := m.(*configuration.Config)
+	ctx := config.Session.GetSessionContext()
+
+	networks, resp, err := config.Session.Client.NetworksApi.FindNetworks(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for existing Networks: %s", configuration.APIError(err))
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Error Response while checking for existing Networks: code=%v", resp.StatusCode)
+	}
+
+	for _, n := range networks {
+		if n.Name == name {
+			return &n, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkNetworkNameAvailable returns an error asking for the existing network to
+// be imported when a network with the same name already exists in the account.
+func checkNetworkNameAvailable(name string, accountId string, m interface{}) error {
+
+	n, err := findNetworkByName(name, accountId, m)
+	if err != nil {
+		return err
+	}
+
+	if n != nil {
+		return fmt.Errorf("A Network with the name %q already exists (%s) - to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for \"pureport_network\" for more information.", name, n.Id)
+	}
+
+	return nil
+}
+
 func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
 
 	config := m.(*configuration.Config)
@@ -124,12 +191,12 @@ func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
 
 	n, resp, err := config.Session.Client.NetworksApi.GetNetwork(ctx, networkId)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			log.Printf("Error Response while reading Network: code=%v", resp.StatusCode)
+		if connection.IsNotFound(resp) {
+			log.Printf("[Info] Network %s not found, removing it from the State", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error reading data for Network: %s", err)
+		return fmt.Errorf("Error reading data for Network: %s", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
@@ -152,21 +219,19 @@ func resourceNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 
 	n := expandNetwork(d)
 
-	d.Partial(true)
-
-	if d.HasChange("name") {
-		n.Name = d.Get("name").(string)
-	}
-
-	if d.HasChange("description") {
-		n.Description = d.Get("description").(string)
-	}
-
+	// Removing all tags has to send an empty map, not omit the tags
 	if d.HasChange("tags") {
 		_, nraw := d.GetChange("tags")
 		n.Tags = tags.FilterTags(nraw.(map[string]interface{}))
 	}
 
+	if d.HasChange("name") {
+		accountId := filepath.Base(d.Get("account_href").(string))
+		if err := checkNetworkNameAvailable(n.Name, accountId, m); err != nil {
+			return err
+		}
+	}
+
 	config := m.(*configuration.Config)
 	ctx := config.Session.GetSessionContext()
 
@@ -174,6 +239,8 @@ func resourceNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 		Body: optional.NewInterface(n),
 	}
 
+	d.Partial(true)
+
 	_, resp, err := config.Session.Client.NetworksApi.UpdateNetwork(
 		ctx,
 		d.Id(),
@@ -181,28 +248,15 @@ func resourceNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 	)
 
 	if err != nil {
-
-		if swerr, ok := err.(client.GenericSwaggerError); ok {
-
-			json_response := string(swerr.Body()[:])
-			response, jerr := structure.ExpandJsonFromString(json_response)
-
-			if jerr == nil {
-				statusCode := int(response["status"].(float64))
-				log.Printf("Error updating Network: %d\n", statusCode)
-				log.Printf("  %s\n", response["code"])
-				log.Printf("  %s\n", response["message"])
-			}
-		}
-
-		return fmt.Errorf("Error while updating Network: err=%s", err)
+		return fmt.Errorf("Error while updating Network: err=%s", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while updating Network : code=%v", resp.StatusCode)
+		return fmt.Errorf("Error Response while updating Network: code=%v", resp.StatusCode)
 	}
 
 	d.Partial(false)
+
 	return resourceNetworkRead(d, m)
 }
 
@@ -215,12 +269,18 @@ func resourceNetworkDelete(d *schema.ResourceData, m interface{}) error {
 	// Delete
 	resp, err := config.Session.Client.NetworksApi.DeleteNetwork(ctx, networkId)
 
+	if connection.IsNotFound(resp) {
+		log.Printf("[Info] Network %s already deleted", networkId)
+		d.SetId("")
+		return nil
+	}
+
 	if err != nil {
-		return fmt.Errorf("Error deleting Network: %s", err)
+		return fmt.Errorf("Error deleting Network: %s", configuration.APIError(err))
 	}
 
 	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while Network: code=%v", resp.StatusCode)
+		return fmt.Errorf("Error Response while deleting Network: code=%v", resp.StatusCode)
 	}
 
 	d.SetId("")
@@ -58,6 +58,20 @@ resource "pureport_network" "main" {
 }
 `
 
+const testAccResourceNetworkConfig_update = testAccResourceNetworkConfig_common + `
+resource "pureport_network" "main" {
+  name = "NetworkTest Renamed"
+  description = "Network Terraform Test Updated"
+  account_href = "${data.pureport_accounts.main.accounts.0.href}"
+
+  tags = {
+    Environment = "tf-test"
+    Owner       = "scott-pilgram"
+    sweep       = "TRUE"
+  }
+}
+`
+
 func TestResourceNetwork_basic(t *testing.T) {
 
 	resourceName := "pureport_network.main"
@@ -82,6 +96,20 @@ func TestResourceNetwork_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "the-rockit"),
 				),
 			},
+			{
+				Config: testAccResourceNetworkConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &instance.Id),
+					resource.TestCheckResourceAttr(resourceName, "name", "NetworkTest Renamed"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Network Terraform Test Updated"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "scott-pilgram"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -6,11 +6,10 @@ import (
 	"net/url"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/structure"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/pureport/pureport-sdk-go/pureport/client"
 	"github.com/pureport/terraform-provider-pureport/pureport/configuration"
@@ -21,11 +20,6 @@ import (
 func resourceSiteVPNConnection() *schema.Resource {
 
 	connection_schema := map[string]*schema.Schema{
-		"speed": {
-			Type:         schema.TypeInt,
-			Required:     true,
-			ValidateFunc: validation.IntInSlice([]int{50, 100, 200, 300, 400, 500, 1000, 10000}),
-		},
 		"ike_version": {
 			Type:         schema.TypeString,
 			Required:     true,
@@ -167,11 +161,24 @@ func resourceSiteVPNConnection() *schema.Resource {
 		Update: resourceSiteVPNConnectionUpdate,
 		Delete: resourceSiteVPNConnectionDelete,
 
+		CustomizeDiff: customdiff.All(
+			connection.ValidateHighAvailabilityDowngrade,
+			connection.ForceNewSpeed("SITE_IPSEC_VPN"),
+			connection.ValidateSpeed("SITE_IPSEC_VPN"),
+			connection.ValidateBillingTerm("SITE_IPSEC_VPN"),
+			connection.EstimateCost("SITE_IPSEC_VPN"),
+		),
+
+		Importer: &schema.ResourceImporter{
+			State: connection.ImportConnection(connection.SiteVPNConnectionName),
+		},
+
 		Schema: connection_schema,
 
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(6 * time.Minute),
-			Delete: schema.DefaultTimeout(6 * time.Minute),
+			Create: schema.DefaultTimeout(connection.DefaultTimeout),
+			Update: schema.DefaultTimeout(connection.DefaultTimeout),
+			Delete: schema.DefaultTimeout(connection.DefaultTimeout),
 		},
 	}
 }
@@ -295,7 +302,7 @@ func expandIkeVersion2(d *schema.ResourceData) *client.Ikev2Config {
 func expandSiteVPNConnection(d *schema.ResourceData) client.SiteIpSecVpnConnection {
 
 	// Generic Connection values
-	speed := d.Get("speed").(int)
+	speed := connection.GetSpeed(d)
 
 	// Create the body of the request
 	c := client.SiteIpSecVpnConnection{
@@ -370,6 +377,16 @@ func expandSiteVPNConnection(d *schema.ResourceData) client.SiteIpSecVpnConnecti
 
 func resourceSiteVPNConnectionCreate(d *schema.ResourceData, m interface{}) error {
 
+	if err := connection.ProvisionConnection(connection.SiteVPNConnectionName, d, m, resourceSiteVPNConnectionAdd); err != nil {
+		return err
+	}
+
+	return resourceSiteVPNConnectionRead(d, m)
+}
+
+// resourceSiteVPNConnectionAdd requests the new connection from the API
+func resourceSiteVPNConnectionAdd(d *schema.ResourceData, m interface{}) error {
+
 	c := expandSiteVPNConnection(d)
 
 	config := m.(*configuration.Config)
@@ -386,25 +403,10 @@ func resourceSiteVPNConnectionCreate(d *schema.ResourceData, m interface{}) erro
 	)
 
 	if err != nil {
-
-		http_err := err
-		json_response := string(err.(client.GenericSwaggerError).Body()[:])
-		response, err := structure.ExpandJsonFromString(json_response)
-		if err != nil {
-			log.Printf("Error Creating new %s: %v", connection.SiteVPNConnectionName, err)
-		} else {
-			statusCode := int(response["status"].(float64))
-			log.Printf("Error Creating new %s: %d\n", connection.SiteVPNConnectionName, statusCode)
-			log.Printf("  %s\n", response["code"])
-			log.Printf("  %s\n", response["message"])
-		}
-
-		d.SetId("")
-		return fmt.Errorf("Error while creating %s: err=%s", connection.SiteVPNConnectionName, http_err)
+		return connection.CreateError(connection.SiteVPNConnectionName, resp, err)
 	}
 
 	if resp.StatusCode >= 300 {
-		d.SetId("")
 		return fmt.Errorf("Error while creating %s: code=%v", connection.SiteVPNConnectionName, resp.StatusCode)
 	}
 
@@ -422,75 +424,35 @@ func resourceSiteVPNConnectionCreate(d *schema.ResourceData, m interface{}) erro
 		return fmt.Errorf("Error when decoding Connection ID")
 	}
 
-	if err := connection.WaitForConnection(connection.SiteVPNConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.SiteVPNConnectionName, err)
-	}
-
-	return resourceSiteVPNConnectionRead(d, m)
+	return nil
 }
 
 func resourceSiteVPNConnectionRead(d *schema.ResourceData, m interface{}) error {
 
-	config := m.(*configuration.Config)
-	connectionId := d.Id()
-	ctx := config.Session.GetSessionContext()
+	c, err := connection.ReadConnection(connection.SiteVPNConnectionName, d, m)
+	if err != nil || c == nil {
+		return err
+	}
 
-	c, resp, err := config.Session.Client.ConnectionsApi.GetConnection(ctx, connectionId)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			log.Printf("Error Response while reading %s: code=%v", connection.SiteVPNConnectionName, resp.StatusCode)
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("Error reading data for %s: %s", connection.SiteVPNConnectionName, err)
+	conn, ok := c.(client.SiteIpSecVpnConnection)
+	if !ok {
+		return fmt.Errorf("Error reading %s %s: connection is not a %s", connection.SiteVPNConnectionName, d.Id(), connection.SiteVPNConnectionName)
 	}
 
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while reading %s: code=%v", connection.SiteVPNConnectionName, resp.StatusCode)
+	if err := connection.FlattenConnection(connection.SiteVPNConnectionName, d, conn); err != nil {
+		return err
 	}
 
-	conn := c.(client.SiteIpSecVpnConnection)
+	connection.ReadConnectionAccount(connection.SiteVPNConnectionName, d, m)
+
 	d.Set("auth_type", conn.AuthType)
-	d.Set("description", conn.Description)
 	d.Set("enable_bgp_password", conn.EnableBGPPassword)
-	d.Set("high_availability", conn.HighAvailability)
-	d.Set("href", conn.Href)
 	d.Set("ike_version", conn.IkeVersion)
-	d.Set("name", conn.Name)
 	d.Set("primary_customer_router_ip", conn.PrimaryCustomerRouterIP)
 	d.Set("primary_key", conn.PrimaryKey)
 	d.Set("routing_type", conn.RoutingType)
 	d.Set("secondary_customer_router_ip", conn.SecondaryCustomerRouterIP)
 	d.Set("secondary_key", conn.SecondaryKey)
-	d.Set("speed", conn.Speed)
-	d.Set("state", conn.State)
-
-	// Add Gateway information
-	var gateways []map[string]interface{}
-	if g := conn.PrimaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenVpnGateway(g))
-	}
-	if g := conn.SecondaryGateway; g != nil {
-		gateways = append(gateways, connection.FlattenVpnGateway(g))
-	}
-	if err := d.Set("gateways", gateways); err != nil {
-		return fmt.Errorf("Error setting gateway information for %s %s: %s", connection.SiteVPNConnectionName, d.Id(), err)
-	}
-
-	if err := d.Set("customer_networks", connection.FlattenCustomerNetworks(conn.CustomerNetworks)); err != nil {
-		return fmt.Errorf("Error setting customer networks for %s %s: %s", connection.SiteVPNConnectionName, d.Id(), err)
-	}
-
-	if err := d.Set("nat_config", connection.FlattenNatConfig(conn.Nat)); err != nil {
-		return fmt.Errorf("Error setting NAT Configuration for %s %s: %s", connection.SiteVPNConnectionName, d.Id(), err)
-	}
-
-	if err := d.Set("location_href", conn.Location.Href); err != nil {
-		return fmt.Errorf("Error setting location for %s %s: %s", connection.SiteVPNConnectionName, d.Id(), err)
-	}
-	if err := d.Set("network_href", conn.Network.Href); err != nil {
-		return fmt.Errorf("Error setting network for %s %s: %s", connection.SiteVPNConnectionName, d.Id(), err)
-	}
 
 	if conn.IkeVersion == "V1" {
 		if err := d.Set("ike_config", []map[string]interface{}{
@@ -562,131 +524,16 @@ func resourceSiteVPNConnectionUpdate(d *schema.ResourceData, m interface{}) erro
 
 	c := expandSiteVPNConnection(d)
 
-	d.Partial(true)
-
-	config := m.(*configuration.Config)
-	ctx := config.Session.GetSessionContext()
-
-	if d.HasChange("name") {
-		c.Name = d.Get("name").(string)
-		d.SetPartial("name")
-	}
-
-	if d.HasChange("description") {
-		c.Description = d.Get("description").(string)
-		d.SetPartial("description")
-	}
-
-	if d.HasChange("speed") {
-		c.Speed = int32(d.Get("speed").(int))
-		d.SetPartial("speed")
-	}
-
-	if d.HasChange("customer_networks") {
-		c.CustomerNetworks = connection.ExpandCustomerNetworks(d)
-	}
-
-	if d.HasChange("nat_config") {
-		c.Nat = connection.ExpandNATConfiguration(d)
-	}
-
-	if d.HasChange("billing_term") {
-		c.BillingTerm = d.Get("billing_term").(string)
-	}
-
-	if d.HasChange("enable_bgp_password") {
-		c.EnableBGPPassword = d.Get("enable_bgp_password").(bool)
-	}
-
-	if d.HasChange("ike_version") {
-		c.IkeVersion = d.Get("ike_version").(string)
-
-		if c.IkeVersion == "V1" {
-			c.IkeV1 = expandIkeVersion1(d)
-			c.IkeV2 = nil
-		} else {
-			c.IkeV2 = expandIkeVersion2(d)
-			c.IkeV1 = nil
-		}
-	}
-
-	if d.HasChange("ike_config") {
-		if c.IkeVersion == "V1" {
-			c.IkeV1 = expandIkeVersion1(d)
-			c.IkeV2 = nil
-		} else {
-			c.IkeV2 = expandIkeVersion2(d)
-			c.IkeV1 = nil
-		}
-	}
-
-	if d.HasChange("primary_customer_router_ip") {
-		c.PrimaryCustomerRouterIP = d.Get("primary_customer_router_ip").(string)
-	}
-
-	if d.HasChange("primary_key") {
-		c.PrimaryKey = d.Get("primary_key").(string)
-	}
-
-	if d.HasChange("routing_type") {
-		c.RoutingType = d.Get("routing_type").(string)
-	}
-
-	if d.HasChange("secondary_customer_router_ip") {
-		c.SecondaryCustomerRouterIP = d.Get("secondary_customer_router_ip").(string)
-	}
-
-	if d.HasChange("secondary_key") {
-		c.SecondaryKey = d.Get("secondary_key").(string)
-	}
-
-	if d.HasChange("traffic_selectors") {
-		c.TrafficSelectors = expandTrafficSelectorMappings(d)
-	}
-
+	// Removing all tags has to send an empty map, not omit the tags
 	if d.HasChange("tags") {
 		_, nraw := d.GetChange("tags")
 		c.Tags = tags.FilterTags(nraw.(map[string]interface{}))
 	}
 
-	opts := client.UpdateConnectionOpts{
-		Body: optional.NewInterface(c),
+	if err := connection.UpdateConnection(connection.SiteVPNConnectionName, d, m, c); err != nil {
+		return err
 	}
 
-	_, resp, err := config.Session.Client.ConnectionsApi.UpdateConnection(
-		ctx,
-		d.Id(),
-		&opts,
-	)
-
-	if err != nil {
-
-		if swerr, ok := err.(client.GenericSwaggerError); ok {
-
-			json_response := string(swerr.Body()[:])
-			response, jerr := structure.ExpandJsonFromString(json_response)
-
-			if jerr == nil {
-				statusCode := int(response["status"].(float64))
-				log.Printf("Error updating %s: %d\n", connection.SiteVPNConnectionName, statusCode)
-				log.Printf("  %s\n", response["code"])
-				log.Printf("  %s\n", response["message"])
-			}
-		}
-
-		return fmt.Errorf("Error while updating %s: err=%s", connection.SiteVPNConnectionName, err)
-	}
-
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("Error Response while updating %s: code=%v", connection.SiteVPNConnectionName, resp.StatusCode)
-	}
-
-	if err := connection.WaitForConnection(connection.SiteVPNConnectionName, d, m); err != nil {
-		return fmt.Errorf("Error waiting for %s: err=%s", connection.SiteVPNConnectionName, err)
-	}
-
-	d.Partial(false)
-
 	return resourceSiteVPNConnectionRead(d, m)
 }
 
@@ -265,6 +265,7 @@ func TestResourceSiteVPNConnection_route_based_bgp(t *testing.T) {
 						resource.TestMatchResourceAttr(resourceName, "gateways.0.pureport_vti_ip", regexp.MustCompile("169.254.[0-9]{1,3}.[0-9]{1,3}")),
 						resource.TestCheckResourceAttr(resourceName, "gateways.0.vpn_auth_type", "PSK"),
 						resource.TestCheckResourceAttrSet(resourceName, "gateways.0.vpn_auth_key"),
+						resource.TestCheckResourceAttrSet(resourceName, "gateways.0.ipsec_status"),
 					),
 
 					resource.ComposeAggregateTestCheckFunc(
@@ -284,6 +285,7 @@ func TestResourceSiteVPNConnection_route_based_bgp(t *testing.T) {
 						resource.TestMatchResourceAttr(resourceName, "gateways.1.pureport_vti_ip", regexp.MustCompile("169.254.[0-9]{1,3}.[0-9]{1,3}")),
 						resource.TestCheckResourceAttr(resourceName, "gateways.1.vpn_auth_type", "PSK"),
 						resource.TestCheckResourceAttrSet(resourceName, "gateways.1.vpn_auth_key"),
+						resource.TestCheckResourceAttrSet(resourceName, "gateways.1.ipsec_status"),
 					),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "tf-test"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "ksk-tibb"),
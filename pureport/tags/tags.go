@@ -36,3 +36,26 @@ func FilterTags(tags map[string]interface{}) (out map[string]string) {
 
 	return
 }
+
+// TagsFilterSchema is used by data sources to only include objects with the tags
+func TagsFilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Only include objects that have all of these tags with the same values.",
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// MatchTags returns whether the tags include all of the required tags with the
+// same values.
+func MatchTags(required map[string]interface{}, tags map[string]string) bool {
+
+	for k, v := range FilterTags(required) {
+		if actual, ok := tags[k]; !ok || actual != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,30 @@
+package tags
+
+import (
+	"testing"
+)
+
+func TestMatchTags(t *testing.T) {
+
+	tags := map[string]string{
+		"Environment": "production",
+		"Team":        "network",
+	}
+
+	cases := []struct {
+		Required map[string]interface{}
+		Expected bool
+	}{
+		{Required: map[string]interface{}{}, Expected: true},
+		{Required: map[string]interface{}{"Environment": "production"}, Expected: true},
+		{Required: map[string]interface{}{"Environment": "production", "Team": "network"}, Expected: true},
+		{Required: map[string]interface{}{"Environment": "staging"}, Expected: false},
+		{Required: map[string]interface{}{"Owner": "network"}, Expected: false},
+	}
+
+	for _, c := range cases {
+		if v := MatchTags(c.Required, tags); v != c.Expected {
+			t.Errorf("%v: expected %v, got %v", c.Required, c.Expected, v)
+		}
+	}
+}